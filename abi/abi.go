@@ -0,0 +1,44 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package abi provides helpers to encode contract calls and decode return data and events.
+package abi
+
+import (
+	"math/big"
+
+	"meter-go/meter"
+)
+
+// Selector returns the 4-byte function selector of the given signature,
+// which is the first 4 bytes of keccak256(signature).
+func Selector(sig string) ([]byte, error) {
+	m, err := NewMethod(sig)
+	if err != nil {
+		return nil, err
+	}
+	id := m.ID()
+	return id[:], nil
+}
+
+// EncodeCall encodes call data for the function signature with args.
+// Supported types are address, uintN, intN, bool, bytesN, bytes and string.
+func EncodeCall(sig string, args ...interface{}) ([]byte, error) {
+	m, err := NewMethod(sig)
+	if err != nil {
+		return nil, err
+	}
+	return m.Pack(args...)
+}
+
+// EncodeTransfer encodes call data of ERC-20 'transfer(address,uint256)'.
+// It panics if amount is negative or exceeds 256 bits.
+func EncodeTransfer(to meter.Address, amount *big.Int) []byte {
+	data, err := EncodeCall("transfer(address,uint256)", to, amount)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"meter-go/meter"
+)
+
+// words joins hex encoded words, ignoring spaces.
+func words(t *testing.T, ws ...string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.ReplaceAll(strings.Join(ws, ""), " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSelector(t *testing.T) {
+	tests := []struct {
+		sig  string
+		want string
+	}{
+		{"transfer(address,uint256)", "a9059cbb"},
+		{"transfer(address to, uint256 amount) returns (bool)", "a9059cbb"},
+		{"balanceOf(address)", "70a08231"},
+		{"approve(address,uint256)", "095ea7b3"},
+	}
+	for _, tt := range tests {
+		sel, err := Selector(tt.sig)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.sig, err)
+		}
+		if got := hex.EncodeToString(sel); got != tt.want {
+			t.Errorf("%s: selector = %s, want %s", tt.sig, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeTransfer(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	amount, _ := new(big.Int).SetString("1000000000000000000", 10)
+
+	want := words(t,
+		"a9059cbb",
+		"0000000000000000000000007567d83b7b8d80addcb281a71d54fc7b3364ffed",
+		"0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+	)
+	if got := EncodeTransfer(to, amount); !bytes.Equal(got, want) {
+		t.Errorf("EncodeTransfer = %x, want %x", got, want)
+	}
+}
+
+func TestEncodeCall(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  string
+		args []interface{}
+		want []string // words after the selector
+	}{
+		{
+			"empty bytes32",
+			"f(bytes32,uint256)",
+			[]interface{}{[]byte{}, 1},
+			[]string{
+				"0000000000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			},
+		},
+		{
+			"nil bytes4",
+			"f(bytes4,bool)",
+			[]interface{}{[]byte(nil), true},
+			[]string{
+				"0000000000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+			},
+		},
+		{
+			"short bytes32",
+			"f(bytes32)",
+			[]interface{}{[]byte{0xde, 0xad}},
+			[]string{
+				"dead000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+		{
+			"dynamic layout",
+			"f(string,uint256,bytes)",
+			[]interface{}{"dave", 1, []byte{1, 2, 3}},
+			[]string{
+				"0000000000000000000000000000000000000000000000000000000000000060",
+				"0000000000000000000000000000000000000000000000000000000000000001",
+				"00000000000000000000000000000000000000000000000000000000000000a0",
+				"0000000000000000000000000000000000000000000000000000000000000004",
+				"6461766500000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000003",
+				"0102030000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+		{
+			"empty string",
+			"f(string,bytes32)",
+			[]interface{}{"", []byte{}},
+			[]string{
+				"0000000000000000000000000000000000000000000000000000000000000040",
+				"0000000000000000000000000000000000000000000000000000000000000000",
+				"0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+		{
+			"long bytes",
+			"f(bytes)",
+			[]interface{}{bytes.Repeat([]byte{0xff}, 33)},
+			[]string{
+				"0000000000000000000000000000000000000000000000000000000000000020",
+				"0000000000000000000000000000000000000000000000000000000000000021",
+				"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+				"ff00000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeCall(tt.sig, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sel, _ := Selector(tt.sig)
+			if !bytes.Equal(data[:4], sel) {
+				t.Fatalf("selector = %x, want %x", data[:4], sel)
+			}
+			if want := words(t, tt.want...); !bytes.Equal(data[4:], want) {
+				t.Errorf("encoded =\n%x\nwant\n%x", data[4:], want)
+			}
+		})
+	}
+}
+
+func TestEncodeCallErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  string
+		args []interface{}
+	}{
+		{"arg count", "f(uint256)", nil},
+		{"uint8 overflow", "f(uint8)", []interface{}{256}},
+		{"negative uint", "f(uint256)", []interface{}{-1}},
+		{"int8 overflow", "f(int8)", []interface{}{128}},
+		{"int8 underflow", "f(int8)", []interface{}{-129}},
+		{"bytes4 too long", "f(bytes4)", []interface{}{[]byte{1, 2, 3, 4, 5}}},
+		{"wrong type", "f(address)", []interface{}{"0x00"}},
+		{"bad type", "f(uint7)", []interface{}{1}},
+	}
+	for _, tt := range tests {
+		if _, err := EncodeCall(tt.sig, tt.args...); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
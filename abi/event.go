@@ -0,0 +1,110 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+
+	"meter-go/meter"
+)
+
+// Event is a contract event parsed from its signature.
+type Event struct {
+	name   string
+	sig    string
+	id     meter.Bytes32
+	inputs []param
+}
+
+// NewEvent parses an event signature,
+// e.g. "Transfer(address indexed from, address indexed to, uint256 value)".
+func NewEvent(signature string) (*Event, error) {
+	name, inputs, outputs, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) > 0 {
+		return nil, errors.New("event has no outputs")
+	}
+	var indexed int
+	for _, p := range inputs {
+		if p.indexed {
+			indexed++
+		}
+	}
+	if indexed > 3 {
+		return nil, errors.New("too many indexed params")
+	}
+
+	ev := &Event{
+		name:   name,
+		sig:    canonical(name, inputs),
+		inputs: inputs,
+	}
+	ev.id = meter.Keccak256([]byte(ev.sig))
+	return ev, nil
+}
+
+// Name returns event name.
+func (e *Event) Name() string {
+	return e.name
+}
+
+// Signature returns the canonical signature, e.g. "Transfer(address,address,uint256)".
+func (e *Event) Signature() string {
+	return e.sig
+}
+
+// ID returns the event id, which is topic0 of its logs.
+func (e *Event) ID() meter.Bytes32 {
+	return e.id
+}
+
+// Unpack decodes event log topics and data into values in the declared order.
+// Indexed values are decoded from topics, except that indexed bytes and string
+// are returned as their hash in meter.Bytes32.
+func (e *Event) Unpack(topics []meter.Bytes32, data []byte) ([]interface{}, error) {
+	if len(topics) == 0 || topics[0] != e.id {
+		return nil, errors.New("event id mismatch")
+	}
+
+	var nonIndexed []argType
+	for _, p := range e.inputs {
+		if !p.indexed {
+			nonIndexed = append(nonIndexed, p.typ)
+		}
+	}
+	dataValues, err := unpack(nonIndexed, data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(e.inputs))
+	topicIndex := 1
+	for _, p := range e.inputs {
+		if !p.indexed {
+			values = append(values, dataValues[0])
+			dataValues = dataValues[1:]
+			continue
+		}
+		if topicIndex >= len(topics) {
+			return nil, fmt.Errorf("missing topic for param %q", p.name)
+		}
+		topic := topics[topicIndex]
+		topicIndex++
+		if p.typ.isDynamic() {
+			values = append(values, topic)
+			continue
+		}
+		v, err := unpackStatic(p.typ, topic[:])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
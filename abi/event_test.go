@@ -0,0 +1,99 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"meter-go/meter"
+)
+
+func TestEventTransfer(t *testing.T) {
+	ev, err := NewEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ev.Signature(), "Transfer(address,address,uint256)"; got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+	// the well known ERC-20 Transfer topic
+	wantID := meter.MustParseBytes32("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	if ev.ID() != wantID {
+		t.Errorf("id = %v, want %v", ev.ID(), wantID)
+	}
+
+	topics := []meter.Bytes32{
+		wantID,
+		meter.MustParseBytes32("0x0000000000000000000000007567d83b7b8d80addcb281a71d54fc7b3364ffed"),
+		meter.MustParseBytes32("0x000000000000000000000000d3ae78222beadb038203be21ed5ce7c9b1bff602"),
+	}
+	data := words(t, "0000000000000000000000000000000000000000000000000de0b6b3a7640000")
+
+	values, err := ev.Unpack(topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 3 {
+		t.Fatalf("got %d values, want 3", len(values))
+	}
+	if got := values[0].(meter.Address); got != meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed") {
+		t.Errorf("from = %v", got)
+	}
+	if got := values[1].(meter.Address); got != meter.MustParseAddress("0xd3ae78222beadb038203be21ed5ce7c9b1bff602") {
+		t.Errorf("to = %v", got)
+	}
+	if got := values[2].(*big.Int); got.String() != "1000000000000000000" {
+		t.Errorf("value = %v", got)
+	}
+
+	if _, err := ev.Unpack(topics[:2], data); err == nil {
+		t.Error("expected error for missing topic")
+	}
+	if _, err := ev.Unpack(topics[1:], data); err == nil {
+		t.Error("expected error for event id mismatch")
+	}
+	if _, err := ev.Unpack(topics, nil); err == nil {
+		t.Error("expected error for missing data")
+	}
+}
+
+func TestEventIndexedDynamic(t *testing.T) {
+	ev, err := NewEvent("Named(string indexed name, int8 indexed delta, string memo)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nameHash := meter.Keccak256([]byte("meter"))
+	topics := []meter.Bytes32{
+		ev.ID(),
+		nameHash,
+		meter.MustParseBytes32("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe"),
+	}
+	data := words(t,
+		"0000000000000000000000000000000000000000000000000000000000000020",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"6869000000000000000000000000000000000000000000000000000000000000",
+	)
+	values, err := ev.Unpack(topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := values[0].(meter.Bytes32); got != nameHash {
+		t.Errorf("name = %v, want hash %v", got, nameHash)
+	}
+	if got := values[1].(*big.Int); got.Int64() != -2 {
+		t.Errorf("delta = %v, want -2", got)
+	}
+	if got := values[2].(string); got != "hi" {
+		t.Errorf("memo = %q, want hi", got)
+	}
+
+	// dirty high bits in an indexed int8
+	topics[2] = meter.MustParseBytes32("0x00000000000000000000000000000000000000000000000000000000000001fe")
+	if _, err := ev.Unpack(topics, data); err == nil {
+		t.Error("expected out of range error")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+
+	"meter-go/meter"
+)
+
+// Method is a contract method parsed from its signature.
+type Method struct {
+	name    string
+	sig     string
+	id      [4]byte
+	inputs  []param
+	outputs []param
+}
+
+// NewMethod parses a method signature, e.g. "balanceOf(address owner) returns (uint256)".
+// Param names are optional, and outputs are only required to unpack return data.
+func NewMethod(signature string) (*Method, error) {
+	name, inputs, outputs, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	m := &Method{
+		name:    name,
+		sig:     canonical(name, inputs),
+		inputs:  inputs,
+		outputs: outputs,
+	}
+	hash := meter.Keccak256([]byte(m.sig))
+	copy(m.id[:], hash[:4])
+	return m, nil
+}
+
+// Name returns method name.
+func (m *Method) Name() string {
+	return m.name
+}
+
+// Signature returns the canonical signature, e.g. "transfer(address,uint256)".
+func (m *Method) Signature() string {
+	return m.sig
+}
+
+// ID returns the 4-byte selector.
+func (m *Method) ID() [4]byte {
+	return m.id
+}
+
+// Pack encodes call data with args.
+func (m *Method) Pack(args ...interface{}) ([]byte, error) {
+	packed, err := pack(types(m.inputs), args)
+	if err != nil {
+		return nil, err
+	}
+	return append(m.id[:], packed...), nil
+}
+
+// UnpackInput decodes call data, which must start with the method selector.
+func (m *Method) UnpackInput(data []byte) ([]interface{}, error) {
+	if len(data) < len(m.id) || !bytes.Equal(data[:len(m.id)], m.id[:]) {
+		return nil, errors.New("selector mismatch")
+	}
+	return unpack(types(m.inputs), data[len(m.id):])
+}
+
+// Unpack decodes return data according to outputs.
+// Values are returned as meter.Address, *big.Int, bool, []byte or string.
+func (m *Method) Unpack(data []byte) ([]interface{}, error) {
+	return unpack(types(m.outputs), data)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"meter-go/meter"
+)
+
+func TestMethodRoundTrip(t *testing.T) {
+	minInt256 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		sig  string
+		args []interface{}
+		want []interface{}
+	}{
+		{
+			"f(address,uint256,bool)",
+			[]interface{}{meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed"), maxUint256, true},
+			[]interface{}{meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed"), maxUint256, true},
+		},
+		{
+			"f(uint8,int8,int8,int16)",
+			[]interface{}{255, -128, 127, -1},
+			[]interface{}{big.NewInt(255), big.NewInt(-128), big.NewInt(127), big.NewInt(-1)},
+		},
+		{
+			"f(int256,int64)",
+			[]interface{}{minInt256, int64(-1) << 63},
+			[]interface{}{minInt256, big.NewInt(-1 << 63)},
+		},
+		{
+			"f(bytes4,bytes,string)",
+			[]interface{}{[]byte{1, 2}, []byte{3, 4, 5}, "meter"},
+			[]interface{}{[]byte{1, 2, 0, 0}, []byte{3, 4, 5}, "meter"},
+		},
+	}
+	for _, tt := range tests {
+		m, err := NewMethod(tt.sig)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.sig, err)
+		}
+		data, err := m.Pack(tt.args...)
+		if err != nil {
+			t.Fatalf("%s: pack: %v", tt.sig, err)
+		}
+		got, err := m.UnpackInput(data)
+		if err != nil {
+			t.Fatalf("%s: unpack: %v", tt.sig, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.sig, got, tt.want)
+		}
+	}
+}
+
+func TestMethodUnpack(t *testing.T) {
+	m, err := NewMethod("balanceOf(address owner) returns (uint256)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := m.Unpack(words(t, "00000000000000000000000000000000000000000000000000000000000003e8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0].(*big.Int).Int64() != 1000 {
+		t.Errorf("got %v, want [1000]", values)
+	}
+
+	if _, err := m.UnpackInput(words(t, "a9059cbb")); err == nil {
+		t.Error("expected selector mismatch")
+	}
+	if _, err := m.Unpack(words(t, "03e8")); err == nil {
+		t.Error("expected error for short data")
+	}
+}
+
+func TestUnpackRange(t *testing.T) {
+	tests := []struct {
+		typ  string
+		word string
+		want int64 // ignored when err is set
+		err  bool
+	}{
+		{"uint8", "00000000000000000000000000000000000000000000000000000000000000ff", 255, false},
+		{"uint8", "0000000000000000000000000000000000000000000000000000000000000100", 0, true},
+		{"uint32", "ff000000000000000000000000000000000000000000000000000000ffffffff", 0, true},
+		{"int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", -1, false},
+		{"int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", -128, false},
+		{"int8", "000000000000000000000000000000000000000000000000000000000000007f", 127, false},
+		{"int8", "0000000000000000000000000000000000000000000000000000000000000080", 0, true},
+		{"int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f", 0, true},
+		{"int8", "00000000000000000000000000000000000000000000000000000000000000ff", 0, true},
+		{"int16", "0100000000000000000000000000000000000000000000000000000000000001", 0, true},
+		{"int256", "8000000000000000000000000000000000000000000000000000000000000000", 0, false},
+	}
+	for _, tt := range tests {
+		typ, err := parseType(tt.typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := unpackStatic(typ, words(t, tt.word))
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s %s: expected error, got %v", tt.typ, tt.word, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.typ, tt.word, err)
+			continue
+		}
+		if tt.typ != "int256" && v.(*big.Int).Int64() != tt.want {
+			t.Errorf("%s %s: got %v, want %v", tt.typ, tt.word, v, tt.want)
+		}
+	}
+}
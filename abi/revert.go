@@ -0,0 +1,63 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// errorSelector is the selector of Error(string), emitted by revert and require.
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// panicSelector is the selector of Panic(uint256), emitted by solidity >= 0.8 on assertion failures.
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons describes the solidity panic codes.
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized function",
+}
+
+// DecodeRevertReason decodes the reason from the return data of a reverted call,
+// which is either Error(string) or Panic(uint256). It returns false for other data.
+func DecodeRevertReason(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+	selector, args := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		values, err := unpack([]argType{{kind: stringKind, name: "string"}}, args)
+		if err != nil {
+			return "", false
+		}
+		return values[0].(string), true
+	case bytes.Equal(selector, panicSelector):
+		values, err := unpack([]argType{{kind: uintKind, name: "uint256", size: 256}}, args)
+		if err != nil {
+			return "", false
+		}
+		code := values[0].(*big.Int)
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				return fmt.Sprintf("panic: %s (0x%x)", reason, code), true
+			}
+		}
+		return fmt.Sprintf("panic: code 0x%x", code), true
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import "testing"
+
+func TestDecodeRevertReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []string
+		reason string
+		ok     bool
+	}{
+		{
+			// the example from the solidity docs, revert("Not enough Ether provided.")
+			"error",
+			[]string{
+				"08c379a0",
+				"0000000000000000000000000000000000000000000000000000000000000020",
+				"000000000000000000000000000000000000000000000000000000000000001a",
+				"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000",
+			},
+			"Not enough Ether provided.",
+			true,
+		},
+		{
+			"panic",
+			[]string{
+				"4e487b71",
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			},
+			"panic: arithmetic overflow or underflow (0x11)",
+			true,
+		},
+		{
+			"unknown panic",
+			[]string{
+				"4e487b71",
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+			},
+			"panic: code 0xff",
+			true,
+		},
+		{
+			"truncated error",
+			[]string{
+				"08c379a0",
+				"0000000000000000000000000000000000000000000000000000000000000020",
+				"000000000000000000000000000000000000000000000000000000000000001a",
+				"4e6f7420656e6f75",
+			},
+			"",
+			false,
+		},
+		{"custom error", []string{"cafebabe"}, "", false},
+		{"short", []string{"08c3"}, "", false},
+	}
+	for _, tt := range tests {
+		reason, ok := DecodeRevertReason(words(t, tt.data...))
+		if reason != tt.reason || ok != tt.ok {
+			t.Errorf("%s: got (%q, %v), want (%q, %v)", tt.name, reason, ok, tt.reason, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"meter-go/meter"
+)
+
+type typeKind int
+
+const (
+	addressKind typeKind = iota
+	uintKind
+	intKind
+	boolKind
+	fixedBytesKind
+	bytesKind
+	stringKind
+)
+
+// wordSize is the size of an ABI word in bytes.
+const wordSize = 32
+
+// argType describes an ABI parameter type.
+type argType struct {
+	kind typeKind
+	size int // bit size for intN/uintN, byte size for bytesN
+	name string
+}
+
+// isDynamic returns whether the type is encoded in the tail part.
+func (t argType) isDynamic() bool {
+	return t.kind == bytesKind || t.kind == stringKind
+}
+
+// parseType parses a type name, e.g. "uint256", "address".
+func parseType(s string) (argType, error) {
+	switch {
+	case s == "address":
+		return argType{addressKind, 160, s}, nil
+	case s == "bool":
+		return argType{boolKind, 8, s}, nil
+	case s == "bytes":
+		return argType{bytesKind, 0, s}, nil
+	case s == "string":
+		return argType{stringKind, 0, s}, nil
+	case s == "uint" || s == "int":
+		return parseType(s + "256")
+	case strings.HasPrefix(s, "uint"), strings.HasPrefix(s, "int"):
+		kind, prefix := intKind, "int"
+		if s[0] == 'u' {
+			kind, prefix = uintKind, "uint"
+		}
+		size, err := strconv.Atoi(s[len(prefix):])
+		if err != nil || size <= 0 || size > 256 || size%8 != 0 {
+			return argType{}, fmt.Errorf("invalid type %q", s)
+		}
+		return argType{kind, size, s}, nil
+	case strings.HasPrefix(s, "bytes"):
+		size, err := strconv.Atoi(s[len("bytes"):])
+		if err != nil || size <= 0 || size > 32 {
+			return argType{}, fmt.Errorf("invalid type %q", s)
+		}
+		return argType{fixedBytesKind, size, s}, nil
+	}
+	return argType{}, fmt.Errorf("unsupported type %q", s)
+}
+
+// param is a parameter of a method or an event.
+type param struct {
+	typ     argType
+	name    string
+	indexed bool
+}
+
+// parseParams parses comma separated params like "address indexed from, uint256 value".
+func parseParams(s string) ([]param, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var params []param
+	for _, p := range strings.Split(s, ",") {
+		fields := strings.Fields(p)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty param in %q", s)
+		}
+		t, err := parseType(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		prm := param{typ: t}
+		fields = fields[1:]
+		if len(fields) > 0 && fields[0] == "indexed" {
+			prm.indexed = true
+			fields = fields[1:]
+		}
+		switch len(fields) {
+		case 0:
+		case 1:
+			prm.name = fields[0]
+		default:
+			return nil, fmt.Errorf("invalid param %q", p)
+		}
+		params = append(params, prm)
+	}
+	return params, nil
+}
+
+// parseSignature parses a signature like "transfer(address to, uint256 amount)",
+// optionally followed by outputs like " returns (bool)".
+// It returns the name, inputs and outputs.
+func parseSignature(sig string) (string, []param, []param, error) {
+	sig = strings.TrimSpace(sig)
+	lp := strings.IndexByte(sig, '(')
+	rp := strings.IndexByte(sig, ')')
+	if lp <= 0 || rp < lp {
+		return "", nil, nil, fmt.Errorf("invalid signature %q", sig)
+	}
+	name := strings.TrimSpace(sig[:lp])
+	inputs, err := parseParams(sig[lp+1 : rp])
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	rest := strings.TrimSpace(sig[rp+1:])
+	rest = strings.TrimSpace(strings.TrimPrefix(rest, "returns"))
+	if rest == "" {
+		return name, inputs, nil, nil
+	}
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", nil, nil, fmt.Errorf("invalid signature %q", sig)
+	}
+	outputs, err := parseParams(rest[1 : len(rest)-1])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, inputs, outputs, nil
+}
+
+// canonical returns the canonical signature, e.g. "transfer(address,uint256)",
+// which is hashed into method selector or event topic.
+func canonical(name string, params []param) string {
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		names = append(names, p.typ.name)
+	}
+	return name + "(" + strings.Join(names, ",") + ")"
+}
+
+// types returns types of params.
+func types(params []param) []argType {
+	ts := make([]argType, 0, len(params))
+	for _, p := range params {
+		ts = append(ts, p.typ)
+	}
+	return ts
+}
+
+// pack encodes args according to types, using the standard head/tail layout.
+func pack(types []argType, args []interface{}) ([]byte, error) {
+	if len(types) != len(args) {
+		return nil, fmt.Errorf("argument count mismatch: expected %d, got %d", len(types), len(args))
+	}
+	var (
+		head []byte
+		tail []byte
+	)
+	headSize := len(types) * wordSize
+	for i, t := range types {
+		if t.isDynamic() {
+			data, err := packDynamic(t, args[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %v", i, err)
+			}
+			head = append(head, packUint(big.NewInt(int64(headSize+len(tail))))...)
+			tail = append(tail, data...)
+		} else {
+			word, err := packStatic(t, args[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %v", i, err)
+			}
+			head = append(head, word...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+// packStatic encodes a static type into a single word.
+func packStatic(t argType, arg interface{}) ([]byte, error) {
+	switch t.kind {
+	case addressKind:
+		var addr meter.Address
+		switch v := arg.(type) {
+		case meter.Address:
+			addr = v
+		case *meter.Address:
+			if v == nil {
+				return nil, errors.New("nil address")
+			}
+			addr = *v
+		default:
+			return nil, fmt.Errorf("cannot use %T as address", arg)
+		}
+		return leftPad(addr[:]), nil
+	case boolKind:
+		v, ok := arg.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %T as bool", arg)
+		}
+		if v {
+			return packUint(big.NewInt(1)), nil
+		}
+		return packUint(new(big.Int)), nil
+	case uintKind, intKind:
+		v, err := toBig(arg)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == uintKind {
+			if v.Sign() < 0 || v.BitLen() > t.size {
+				return nil, fmt.Errorf("value %v out of range for %s", v, t.name)
+			}
+			return packUint(v), nil
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size-1))
+		if v.Cmp(limit) >= 0 || v.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %v out of range for %s", v, t.name)
+		}
+		if v.Sign() < 0 {
+			// two's complement
+			v = new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return packUint(v), nil
+	case fixedBytesKind:
+		var b []byte
+		switch v := arg.(type) {
+		case []byte:
+			b = v
+		case meter.Bytes32:
+			b = v[:]
+		default:
+			return nil, fmt.Errorf("cannot use %T as %s", arg, t.name)
+		}
+		if len(b) > t.size {
+			return nil, fmt.Errorf("value too long for %s", t.name)
+		}
+		// always a full word, even for empty values
+		out := make([]byte, wordSize)
+		copy(out, b)
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported static type %s", t.name)
+}
+
+// packDynamic encodes bytes or string into length-prefixed padded words.
+func packDynamic(t argType, arg interface{}) ([]byte, error) {
+	var b []byte
+	switch v := arg.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return nil, fmt.Errorf("cannot use %T as %s", arg, t.name)
+	}
+	out := packUint(big.NewInt(int64(len(b))))
+	if len(b) > 0 {
+		out = append(out, rightPad(b)...)
+	}
+	return out, nil
+}
+
+// toBig converts integer-like values into big.Int.
+func toBig(arg interface{}) (*big.Int, error) {
+	switch v := arg.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("nil big.Int")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	}
+	return nil, fmt.Errorf("cannot use %T as integer", arg)
+}
+
+func packUint(v *big.Int) []byte {
+	return leftPad(v.Bytes())
+}
+
+func leftPad(b []byte) []byte {
+	out := make([]byte, wordSize)
+	copy(out[wordSize-len(b):], b)
+	return out
+}
+
+func rightPad(b []byte) []byte {
+	size := (len(b) + wordSize - 1) / wordSize * wordSize
+	out := make([]byte, size)
+	copy(out, b)
+	return out
+}
+
+// unpack decodes data according to types, the reverse of pack.
+func unpack(types []argType, data []byte) ([]interface{}, error) {
+	if len(data) < len(types)*wordSize {
+		return nil, fmt.Errorf("data too short: expected at least %d bytes, got %d", len(types)*wordSize, len(data))
+	}
+	values := make([]interface{}, 0, len(types))
+	for i, t := range types {
+		word := data[i*wordSize : (i+1)*wordSize]
+		var (
+			v   interface{}
+			err error
+		)
+		if t.isDynamic() {
+			v, err = unpackDynamic(t, data, word)
+		} else {
+			v, err = unpackStatic(t, word)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %v", i, err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// unpackStatic decodes a static type from a single word.
+// Values are returned as meter.Address, *big.Int, bool or []byte.
+func unpackStatic(t argType, word []byte) (interface{}, error) {
+	switch t.kind {
+	case addressKind:
+		var addr meter.Address
+		copy(addr[:], word[wordSize-meter.AddressLength:])
+		return addr, nil
+	case boolKind:
+		v := new(big.Int).SetBytes(word)
+		if v.BitLen() > 1 {
+			return nil, errors.New("invalid bool")
+		}
+		return v.Sign() == 1, nil
+	case uintKind:
+		v := new(big.Int).SetBytes(word)
+		if v.BitLen() > t.size {
+			return nil, fmt.Errorf("value out of range for %s", t.name)
+		}
+		return v, nil
+	case intKind:
+		v := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			// two's complement
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		// narrower types must be sign extended, anything else is dirty high bits
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size-1))
+		if v.Cmp(limit) >= 0 || v.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value out of range for %s", t.name)
+		}
+		return v, nil
+	case fixedBytesKind:
+		return append([]byte(nil), word[:t.size]...), nil
+	}
+	return nil, fmt.Errorf("unsupported static type %s", t.name)
+}
+
+// unpackDynamic decodes bytes or string, located by the offset word.
+func unpackDynamic(t argType, data []byte, offsetWord []byte) (interface{}, error) {
+	offset, err := wordToInt(offsetWord)
+	if err != nil {
+		return nil, err
+	}
+	if offset+wordSize > len(data) {
+		return nil, errors.New("offset out of range")
+	}
+	size, err := wordToInt(data[offset : offset+wordSize])
+	if err != nil {
+		return nil, err
+	}
+	start := offset + wordSize
+	if size > len(data)-start {
+		return nil, errors.New("length out of range")
+	}
+	b := append([]byte(nil), data[start:start+size]...)
+	if t.kind == stringKind {
+		return string(b), nil
+	}
+	return b, nil
+}
+
+// wordToInt converts a word into int, erroring on values not fit in int32 to avoid overflows.
+func wordToInt(word []byte) (int, error) {
+	v := new(big.Int).SetBytes(word)
+	if v.BitLen() > 31 {
+		return 0, errors.New("offset or length too large")
+	}
+	return int(v.Int64()), nil
+}
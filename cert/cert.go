@@ -0,0 +1,102 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package cert implements the certificate of signed dapp requests, compatible with Thor's (connex) certificate.
+package cert
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Payload is the content of a certificate.
+type Payload struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// Certificate is a message signed by a user to the dapp at Domain.
+type Certificate struct {
+	Purpose   string        `json:"purpose"` // e.g. identification, agreement
+	Payload   Payload       `json:"payload"`
+	Domain    string        `json:"domain"`
+	Timestamp uint64        `json:"timestamp"`
+	Signer    meter.Address `json:"signer"`
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+}
+
+// encode returns the canonical json of cert excluding the signature,
+// with keys sorted and the signer in lower case, as connex does.
+func (c *Certificate) encode() ([]byte, error) {
+	// maps are encoded with sorted keys
+	obj := map[string]interface{}{
+		"purpose": c.Purpose,
+		"payload": map[string]interface{}{
+			"type":    c.Payload.Type,
+			"content": c.Payload.Content,
+		},
+		"domain":    c.Domain,
+		"timestamp": c.Timestamp,
+		"signer":    strings.ToLower(c.Signer.String()),
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// SigningHash returns the hash to be signed, which is blake2b of the canonical json.
+func (c *Certificate) SigningHash() (meter.Bytes32, error) {
+	data, err := c.encode()
+	if err != nil {
+		return meter.Bytes32{}, err
+	}
+	return meter.Blake2b(data), nil
+}
+
+// Sign sets the signer to the address of priv, and signs the cert.
+func (c *Certificate) Sign(priv *ecdsa.PrivateKey) error {
+	c.Signer = meter.Address(crypto.PubkeyToAddress(priv.PublicKey))
+	hash, err := c.SigningHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash[:], priv)
+	if err != nil {
+		return err
+	}
+	c.Signature = sig
+	return nil
+}
+
+// Verify checks the signature is made by Signer.
+func (c *Certificate) Verify() error {
+	if len(c.Signature) != 65 {
+		return errors.New("invalid signature length")
+	}
+	hash, err := c.SigningHash()
+	if err != nil {
+		return err
+	}
+	pub, err := crypto.SigToPub(hash[:], c.Signature)
+	if err != nil {
+		return err
+	}
+	if meter.Address(crypto.PubkeyToAddress(*pub)) != c.Signer {
+		return errors.New("signature not match signer")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cert
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testPrivateKey = "7582be841ca040aa940fff6c05773129e135623e41acce3e0b8ba520dc1ae26a"
+
+func newTestCert() *Certificate {
+	return &Certificate{
+		Purpose:   "identification",
+		Payload:   Payload{Type: "text", Content: "fyi"},
+		Domain:    "localhost",
+		Timestamp: 1545035330,
+	}
+}
+
+// TestCertificateVector checks the certificate used in the thor-devkit tests.
+// The encoding, hash and signature were computed independently of this package,
+// with RFC 6979 deterministic signing as in connex.
+func TestCertificateVector(t *testing.T) {
+	priv, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestCert()
+	if err := c.Sign(priv); err != nil {
+		t.Fatal(err)
+	}
+
+	const wantJSON = `{"domain":"localhost","payload":{"content":"fyi","type":"text"},"purpose":"identification",` +
+		`"signer":"0xd989829d88b0ed1b06edf5c50174ecfa64f14a64","timestamp":1545035330}`
+	data, err := c.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != wantJSON {
+		t.Errorf("encoded =\n%s\nwant\n%s", data, wantJSON)
+	}
+
+	hash, err := c.SigningHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := meter.MustParseBytes32("0x699612bb2d72d1052bc89f6bdcda8385c6a7f21f2f9cf81d6793150cc17b8f0e"); hash != want {
+		t.Errorf("signing hash = %v, want %v", hash, want)
+	}
+
+	const wantSig = "390870e4a99a6a80c3903e0bc13fdcaf15ae46d27b6365e3e07275990e3e7495" +
+		"5ad43dba79682b9d0de3a47e96149539b07dde6b51c49a1c7eb6254036b913b000"
+	if got := hex.EncodeToString(c.Signature); got != wantSig {
+		t.Errorf("signature = %s, want %s", got, wantSig)
+	}
+	if err := c.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertificateEncodeNoEscape(t *testing.T) {
+	c := newTestCert()
+	c.Payload.Content = `<a href="x">&</a>`
+	data, err := c.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// JSON.stringify leaves html characters unescaped
+	want := `{"domain":"localhost","payload":{"content":"<a href=\"x\">&</a>","type":"text"},"purpose":"identification",` +
+		`"signer":"0x0000000000000000000000000000000000000000","timestamp":1545035330}`
+	if string(data) != want {
+		t.Errorf("encoded =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestCertificateSignVerify(t *testing.T) {
+	priv, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := newTestCert()
+	if err := signed.Sign(priv); err != nil {
+		t.Fatal(err)
+	}
+
+	// survives a json round trip
+	data, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Certificate
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.Verify(); err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(c *Certificate)
+	}{
+		{"purpose", func(c *Certificate) { c.Purpose = "agreement" }},
+		{"payload type", func(c *Certificate) { c.Payload.Type = "html" }},
+		{"payload content", func(c *Certificate) { c.Payload.Content = "fyi!" }},
+		{"domain", func(c *Certificate) { c.Domain = "evil.com" }},
+		{"timestamp", func(c *Certificate) { c.Timestamp++ }},
+		{"wrong signer", func(c *Certificate) { c.Signer = meter.Address(crypto.PubkeyToAddress(other.PublicKey)) }},
+		{"signed by other", func(c *Certificate) {
+			signer := c.Signer
+			if err := c.Sign(other); err != nil {
+				t.Fatal(err)
+			}
+			c.Signer = signer
+		}},
+		{"signature", func(c *Certificate) { c.Signature[10] ^= 1 }},
+		{"short signature", func(c *Certificate) { c.Signature = c.Signature[:64] }},
+		{"no signature", func(c *Certificate) { c.Signature = nil }},
+	}
+	for _, tt := range tests {
+		c := *signed
+		c.Signature = append([]byte(nil), signed.Signature...)
+		tt.tamper(&c)
+		if err := c.Verify(); err == nil {
+			t.Errorf("%s: tampered cert verified", tt.name)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// Account is the state of an account.
+// On Meter, Energy is the MTR balance used to pay gas, and Balance is the MTRG balance.
+type Account struct {
+	Balance      *big.Int
+	Energy       *big.Int
+	BoundBalance *big.Int
+	BoundEnergy  *big.Int
+	HasCode      bool
+}
+
+type accountJSON struct {
+	Balance      *math.HexOrDecimal256 `json:"balance"`
+	Energy       *math.HexOrDecimal256 `json:"energy"`
+	BoundBalance *math.HexOrDecimal256 `json:"boundbalance"`
+	BoundEnergy  *math.HexOrDecimal256 `json:"boundenergy"`
+	HasCode      bool                  `json:"hasCode"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a *Account) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&accountJSON{
+		(*math.HexOrDecimal256)(a.Balance),
+		(*math.HexOrDecimal256)(a.Energy),
+		(*math.HexOrDecimal256)(a.BoundBalance),
+		(*math.HexOrDecimal256)(a.BoundEnergy),
+		a.HasCode,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	var aj accountJSON
+	if err := json.Unmarshal(data, &aj); err != nil {
+		return err
+	}
+	*a = Account{
+		Balance:      bigOrZero(aj.Balance),
+		Energy:       bigOrZero(aj.Energy),
+		BoundBalance: bigOrZero(aj.BoundBalance),
+		BoundEnergy:  bigOrZero(aj.BoundEnergy),
+		HasCode:      aj.HasCode,
+	}
+	return nil
+}
+
+// GetAccount returns the account state at revision.
+func (c *Client) GetAccount(ctx context.Context, addr meter.Address, rev Revision) (*Account, error) {
+	var acc Account
+	if err := c.httpGet(ctx, "/accounts/"+addr.String(), url.Values{"revision": {rev.String()}}, &acc); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// GetCode returns the code of account at revision, which is empty for an account without code.
+func (c *Client) GetCode(ctx context.Context, addr meter.Address, rev Revision) ([]byte, error) {
+	var res struct {
+		Code hexutil.Bytes `json:"code"`
+	}
+	if err := c.httpGet(ctx, "/accounts/"+addr.String()+"/code", url.Values{"revision": {rev.String()}}, &res); err != nil {
+		return nil, err
+	}
+	if res.Code == nil {
+		return []byte{}, nil
+	}
+	return res.Code, nil
+}
+
+// GetStorage returns the value of the storage slot key of account at revision.
+func (c *Client) GetStorage(ctx context.Context, addr meter.Address, key meter.Bytes32, rev Revision) (meter.Bytes32, error) {
+	var res struct {
+		Value meter.Bytes32 `json:"value"`
+	}
+	path := "/accounts/" + addr.String() + "/storage/" + key.String()
+	if err := c.httpGet(ctx, path, url.Values{"revision": {rev.String()}}, &res); err != nil {
+		return meter.Bytes32{}, err
+	}
+	return res.Value, nil
+}
+
+// maxConcurrentRequests bounds the concurrent requests issued by batch methods.
+const maxConcurrentRequests = 8
+
+// AccountsError is returned by GetAccounts when some of the accounts failed to fetch.
+type AccountsError struct {
+	Errors []error // errors by index of input addresses, nil for fetched ones
+}
+
+func (e *AccountsError) Error() string {
+	var (
+		failed int
+		first  error
+	)
+	for _, err := range e.Errors {
+		if err != nil {
+			if failed++; first == nil {
+				first = err
+			}
+		}
+	}
+	return fmt.Sprintf("failed to get %d of %d accounts: %v", failed, len(e.Errors), first)
+}
+
+// GetAccounts returns states of accounts at revision concurrently, in the order of addrs.
+// If some accounts failed to fetch, their entries are nil and an *AccountsError is returned
+// along with the others. The best revision is pinned to a block first, so all states are consistent.
+func (c *Client) GetAccounts(ctx context.Context, addrs []meter.Address, rev Revision) ([]*Account, error) {
+	if rev.String() == RevisionBest().String() && len(addrs) > 1 {
+		best, err := c.GetBestBlock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if best != nil {
+			rev = RevisionID(best.ID)
+		}
+	}
+
+	var (
+		accs = make([]*Account, len(addrs))
+		errs = make([]error, len(addrs))
+		sem  = make(chan struct{}, maxConcurrentRequests)
+		wg   sync.WaitGroup
+	)
+	for i, addr := range addrs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, addr meter.Address) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			accs[i], errs[i] = c.GetAccount(ctx, addr, rev)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return accs, &AccountsError{Errors: errs}
+		}
+	}
+	return accs, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// Shortfall is the lack of a token of an account.
+type Shortfall struct {
+	Account   meter.Address
+	Token     tx.TokenType
+	Required  *big.Int
+	Available *big.Int
+}
+
+// Amount returns how much more is required.
+func (s *Shortfall) Amount() *big.Int {
+	return new(big.Int).Sub(s.Required, s.Available)
+}
+
+// ShortfallReport lists every token an account lacks to send a tx.
+type ShortfallReport struct {
+	Shortfalls []*Shortfall
+}
+
+func (r *ShortfallReport) String() string {
+	msgs := make([]string, 0, len(r.Shortfalls))
+	for _, s := range r.Shortfalls {
+		msgs = append(msgs, fmt.Sprintf("%v lacks %v of token %d (has %v, requires %v)",
+			s.Account, s.Amount(), s.Token, s.Available, s.Required))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// CanAfford checks at best block whether the tx can be paid, with the base gas price.
+// On Meter, gas is paid in MTR (energy) by the gas payer, while clause values are paid by the
+// origin in the token of each clause, MTR from energy and MTRG from balance.
+// So the origin needs MTR for both gas and MTR values unless the tx is delegated, and MTRG for MTRG values.
+// A report is returned if it can't afford.
+func (c *Client) CanAfford(ctx context.Context, t *tx.Transaction, base *big.Int) (bool, *ShortfallReport, error) {
+	return CanAfford(ctx, c, t, base)
+}
+
+// CanAfford is Client.CanAfford against any backend.
+func CanAfford(ctx context.Context, b Backend, t *tx.Transaction, base *big.Int) (bool, *ShortfallReport, error) {
+	gasCost, values, err := t.Cost(base)
+	if err != nil {
+		return false, nil, err
+	}
+	origin, err := t.Origin()
+	if err != nil {
+		return false, nil, err
+	}
+	if origin == (meter.Address{}) {
+		return false, nil, errors.New("tx not signed")
+	}
+	payer, err := t.GasPayer()
+	if err != nil {
+		return false, nil, err
+	}
+
+	// required amounts by account and token
+	type key struct {
+		addr  meter.Address
+		token tx.TokenType
+	}
+	required := make(map[key]*big.Int)
+	add := func(k key, v *big.Int) {
+		if r, ok := required[k]; ok {
+			r.Add(r, v)
+		} else {
+			required[k] = new(big.Int).Set(v)
+		}
+	}
+	add(key{payer, tx.MeterToken}, gasCost)
+	for token, v := range values {
+		add(key{origin, token}, v)
+	}
+
+	best, err := b.GetBestBlock(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	if best == nil {
+		return false, nil, errors.New("best block not found")
+	}
+	accounts := make(map[meter.Address]*Account)
+	report := &ShortfallReport{}
+	// check in a stable order: payer then origin, MTR then MTRG
+	addrs := []meter.Address{payer}
+	if origin != payer {
+		addrs = append(addrs, origin)
+	}
+	for _, addr := range addrs {
+		for _, token := range []tx.TokenType{tx.MeterToken, tx.MeterGovToken} {
+			r, ok := required[key{addr, token}]
+			if !ok {
+				continue
+			}
+			acc, ok := accounts[addr]
+			if !ok {
+				if acc, err = b.GetAccount(ctx, addr, RevisionID(best.ID)); err != nil {
+					return false, nil, err
+				}
+				accounts[addr] = acc
+			}
+			available := acc.Energy
+			if token == tx.MeterGovToken {
+				available = acc.Balance
+			}
+			if available.Cmp(r) < 0 {
+				report.Shortfalls = append(report.Shortfalls, &Shortfall{
+					Account:   addr,
+					Token:     token,
+					Required:  r,
+					Available: new(big.Int).Set(available),
+				})
+			}
+		}
+	}
+	if len(report.Shortfalls) > 0 {
+		return false, report, nil
+	}
+	return true, nil, nil
+}
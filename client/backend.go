@@ -0,0 +1,38 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"math/big"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// Backend is the network methods of Client, for code to depend on instead of the concrete Client,
+// e.g. to be tested offline with clienttest.MockBackend.
+// Helpers built on these methods, like ValidateTransaction, CanAfford, Submit and Tracker,
+// take a Backend as well.
+type Backend interface {
+	GetBlock(ctx context.Context, rev Revision) (*Block, error)
+	GetBlockExpanded(ctx context.Context, rev Revision) (*ExpandedBlock, error)
+	GetBestBlock(ctx context.Context) (*Block, error)
+	GetAccount(ctx context.Context, addr meter.Address, rev Revision) (*Account, error)
+	GetAccounts(ctx context.Context, addrs []meter.Address, rev Revision) ([]*Account, error)
+	GetCode(ctx context.Context, addr meter.Address, rev Revision) ([]byte, error)
+	GetStorage(ctx context.Context, addr meter.Address, key meter.Bytes32, rev Revision) (meter.Bytes32, error)
+	GetTransaction(ctx context.Context, id meter.Bytes32, opts GetTxOptions) (*ClientTransaction, error)
+	GetTransactionReceipt(ctx context.Context, id meter.Bytes32) (*Receipt, error)
+	SendTransaction(ctx context.Context, t *tx.Transaction) (meter.Bytes32, error)
+	SendRaw(ctx context.Context, raw string) (meter.Bytes32, error)
+	Inspect(ctx context.Context, clauses []*tx.Clause, caller *meter.Address, rev Revision) ([]*CallResult, error)
+	BaseGasPrice(ctx context.Context, rev Revision) (*big.Int, error)
+	FilterEvents(ctx context.Context, criteria EventCriteria) ([]*Event, error)
+	FilterTransfers(ctx context.Context, criteria TransferCriteria) ([]*Transfer, error)
+}
+
+var _ Backend = (*Client)(nil)
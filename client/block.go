@@ -0,0 +1,133 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/url"
+
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// Block is the block summary returned by the node.
+// Proposer is the block signer as recovered by the node. It can't be verified from the api
+// response, which carries neither the header signature nor all header fields covered by it.
+type Block struct {
+	Number       uint32          `json:"number"`
+	ID           meter.Bytes32   `json:"id"`
+	Size         uint32          `json:"size"`
+	ParentID     meter.Bytes32   `json:"parentID"`
+	Timestamp    uint64          `json:"timestamp"`
+	GasLimit     uint64          `json:"gasLimit"`
+	Beneficiary  meter.Address   `json:"beneficiary"`
+	GasUsed      uint64          `json:"gasUsed"`
+	TotalScore   uint64          `json:"totalScore"`
+	TxsRoot      meter.Bytes32   `json:"txsRoot"`
+	StateRoot    meter.Bytes32   `json:"stateRoot"`
+	ReceiptsRoot meter.Bytes32   `json:"receiptsRoot"`
+	Proposer     meter.Address   `json:"signer"`
+	IsTrunk      bool            `json:"isTrunk"`
+	Transactions []meter.Bytes32 `json:"transactions"`
+
+	// Obsolete is only set on subscribed blocks, which marks a block that has been
+	// reverted by a chain fork.
+	Obsolete bool `json:"obsolete,omitempty"`
+}
+
+// BlockNumber returns block number, implements tx.BlockNumberer.
+func (b *Block) BlockNumber() uint32 {
+	return b.Number
+}
+
+// GetBlock returns the block at revision.
+// It returns nil if the block is not found.
+func (c *Client) GetBlock(ctx context.Context, rev Revision) (*Block, error) {
+	var b *Block
+	if err := c.httpGet(ctx, "/blocks/"+rev.String(), nil, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// BlockTransaction is a tx embedded in an expanded block, along with its receipt.
+type BlockTransaction struct {
+	ClientTransaction
+	GasUsed  uint64
+	GasPayer meter.Address
+	Paid     *big.Int
+	Reward   *big.Int
+	Reverted bool
+	Outputs  []*Output
+}
+
+type blockTxJSON struct {
+	txJSON
+	GasUsed  uint64                `json:"gasUsed"`
+	GasPayer meter.Address         `json:"gasPayer"`
+	Paid     *math.HexOrDecimal256 `json:"paid"`
+	Reward   *math.HexOrDecimal256 `json:"reward"`
+	Reverted bool                  `json:"reverted"`
+	Outputs  []*Output             `json:"outputs"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (bt *BlockTransaction) UnmarshalJSON(data []byte) error {
+	var res blockTxJSON
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	*bt = BlockTransaction{
+		ClientTransaction: *res.toClientTransaction(),
+		GasUsed:           res.GasUsed,
+		GasPayer:          res.GasPayer,
+		Paid:              bigOrZero(res.Paid),
+		Reward:            bigOrZero(res.Reward),
+		Reverted:          res.Reverted,
+		Outputs:           res.Outputs,
+	}
+	return nil
+}
+
+// ExpandedBlock is a block with its txs and receipts embedded.
+// The Transactions field shadows the tx ids of the embedded Block, which are left empty.
+type ExpandedBlock struct {
+	Block
+	Transactions []*BlockTransaction `json:"transactions"`
+}
+
+// GetBlockExpanded returns the block at revision with its txs and receipts.
+// It returns nil if the block is not found.
+func (c *Client) GetBlockExpanded(ctx context.Context, rev Revision) (*ExpandedBlock, error) {
+	var b *ExpandedBlock
+	if err := c.httpGet(ctx, "/blocks/"+rev.String(), url.Values{"expanded": {"true"}}, &b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetBestBlock returns the best block.
+func (c *Client) GetBestBlock(ctx context.Context) (*Block, error) {
+	return c.GetBlock(ctx, RevisionBest())
+}
+
+// FreshBlockRef returns the blockRef of the best block.
+// To refresh an unsigned tx, rebuild it with trx.ToBuilder().BlockRef(ref).Build(), and sign again.
+func (c *Client) FreshBlockRef(ctx context.Context) (tx.BlockRef, error) {
+	best, err := c.GetBestBlock(ctx)
+	if err != nil {
+		return tx.BlockRef{}, err
+	}
+	if best == nil {
+		return tx.BlockRef{}, errors.New("best block not found")
+	}
+	return tx.NewBlockRefFromID(best.ID), nil
+}
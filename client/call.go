@@ -0,0 +1,104 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+
+	"meter-go/abi"
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CallResult is the result of a simulated clause.
+type CallResult struct {
+	Data      hexutil.Bytes `json:"data"`
+	Events    []*Event      `json:"events"`
+	Transfers []*Transfer   `json:"transfers"`
+	GasUsed   uint64        `json:"gasUsed"`
+	Reverted  bool          `json:"reverted"`
+	VMError   string        `json:"vmError"`
+}
+
+// RevertReason returns the decoded revert reason of the result, see abi.DecodeRevertReason.
+func (r *CallResult) RevertReason() (string, bool) {
+	if !r.Reverted {
+		return "", false
+	}
+	return abi.DecodeRevertReason(r.Data)
+}
+
+// RevertError is returned when a simulated clause reverts.
+type RevertError struct {
+	ClauseIndex int
+	VMError     string
+	Reason      string // decoded revert reason, empty if not available
+	Data        []byte
+}
+
+// newRevertError creates RevertError from the result of the reverted clause at index i.
+func newRevertError(i int, r *CallResult) *RevertError {
+	reason, _ := r.RevertReason()
+	return &RevertError{ClauseIndex: i, VMError: r.VMError, Reason: reason, Data: r.Data}
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("clause %d reverted: %s: %s", e.ClauseIndex, e.VMError, e.Reason)
+	}
+	return fmt.Sprintf("clause %d reverted: %s", e.ClauseIndex, e.VMError)
+}
+
+type callRequest struct {
+	Clauses []*tx.Clause   `json:"clauses"`
+	Caller  *meter.Address `json:"caller,omitempty"`
+}
+
+// Inspect simulates clauses at revision, the caller is optional.
+func (c *Client) Inspect(ctx context.Context, clauses []*tx.Clause, caller *meter.Address, rev Revision) ([]*CallResult, error) {
+	req := callRequest{
+		Clauses: clauses,
+		Caller:  caller,
+	}
+
+	var results []*CallResult
+	if err := c.httpPost(ctx, "/accounts/*", url.Values{"revision": {rev.String()}}, &req, &results); err != nil {
+		return nil, err
+	}
+	if len(results) != len(clauses) {
+		return nil, errors.New("inspect: result count mismatch")
+	}
+	return results, nil
+}
+
+// EstimateGas simulates clauses by caller at best block, and returns the recommended gas,
+// which is the intrinsic gas plus the VM gas multiplied by the client's gas margin.
+// A *RevertError is returned if any clause reverts.
+func (c *Client) EstimateGas(ctx context.Context, clauses []*tx.Clause, caller meter.Address) (uint64, error) {
+	intrinsicGas, err := tx.IntrinsicGas(clauses...)
+	if err != nil {
+		return 0, err
+	}
+	results, err := c.Inspect(ctx, clauses, &caller, RevisionBest())
+	if err != nil {
+		return 0, err
+	}
+
+	var vmGas uint64
+	for i, r := range results {
+		if r.Reverted {
+			return 0, newRevertError(i, r)
+		}
+		vmGas += r.GasUsed
+	}
+	return intrinsicGas + uint64(math.Ceil(float64(vmGas)*c.gasMargin)), nil
+}
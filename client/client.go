@@ -0,0 +1,362 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package client implements a client of the meter node restful API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"meter-go/tx"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultGasMargin is the default multiplier applied to the VM gas of an estimation.
+	DefaultGasMargin = 1.2
+	// DefaultTimeout is the default timeout of a http request.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxIdleConnsPerHost is the default count of kept-alive connections to the node.
+	DefaultMaxIdleConnsPerHost = 16
+
+	// throttleBackoff is the initial delay before retrying a request throttled with 429.
+	throttleBackoff = 500 * time.Millisecond
+	// maxThrottleRetries is the max retries of a throttled request.
+	maxThrottleRetries = 4
+)
+
+// Client is a client of the meter node restful API.
+// It's safe for concurrent use, as its settings are fixed after creation, cached states are
+// guarded by locks, and the underlying http.Client pools connections across goroutines.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	gasMargin   float64
+	explorerURL string
+	network     Network
+	nodes       []*node
+	next        uint32 // round-robin index of nodes
+	quorum      int    // count of nodes a tx must be accepted by
+
+	logger     Logger
+	onRequest  func(RequestInfo)
+	onResponse func(ResponseInfo)
+
+	paramsLock sync.Mutex
+	params     *tx.ChainParams // cached chain params
+
+	// settings of the default http client
+	timeout             time.Duration
+	maxIdleConnsPerHost int
+	tlsConfig           *tls.Config
+
+	limiter  *rate.Limiter // nil means no limit
+	wsDialer *websocket.Dialer
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithGasMargin set the multiplier applied to the VM gas by EstimateGas.
+// Margins less than 1 are ignored.
+func WithGasMargin(margin float64) ClientOption {
+	return func(c *Client) {
+		if margin >= 1 {
+			c.gasMargin = margin
+		}
+	}
+}
+
+// WithExplorer set the base url of the block explorer, e.g. https://scan.meter.io.
+func WithExplorer(explorerURL string) ClientOption {
+	return func(c *Client) {
+		c.explorerURL = strings.TrimRight(explorerURL, "/")
+	}
+}
+
+// WithHTTPClient set the http client, which overrides WithTimeout, WithMaxIdleConnsPerHost and WithTLSConfig.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout set the timeout of a http request, 0 means no timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithTLSConfig set the TLS config of the default http client and of subscriptions, e.g. with a pinned
+// certificate verified in VerifyPeerCertificate. It forces TLS, so plain http urls are rejected by NewClient.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithRateLimit limits outbound requests to rps per second with bursts of burst requests,
+// e.g. to respect quotas of public nodes. Requests wait for their turn until ctx is done.
+// A non-positive rps means no limit, and burst is raised to at least 1.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		if !(rps > 0) {
+			c.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithMaxIdleConnsPerHost set the count of kept-alive connections to the node.
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		c.maxIdleConnsPerHost = n
+	}
+}
+
+// NewClient create a client with the node's base url, e.g. http://warringstakes.meter.io:8669.
+// The url is validated up front, and https is assumed if the scheme is missing, e.g. for
+// host:port or [::1]:8669.
+func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
+	normalized, err := normalizeURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		baseURL:             normalized,
+		gasMargin:           DefaultGasMargin,
+		explorerURL:         guessExplorerURL(normalized),
+		timeout:             DefaultTimeout,
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.nodes) == 0 {
+		c.nodes = []*node{{url: c.baseURL}}
+	}
+	if c.tlsConfig != nil {
+		for _, n := range c.nodes {
+			if !strings.HasPrefix(n.url, "https://") {
+				return nil, fmt.Errorf("invalid url %q: TLS required", n.url)
+			}
+		}
+	}
+	if c.httpClient == nil {
+		c.httpClient = c.newHTTPClient()
+	}
+	c.wsDialer = c.newWSDialer()
+	return c, nil
+}
+
+// MustNewClient is like NewClient, but panics on invalid url.
+// It eases migration of callers of the former NewClient, which returned no error.
+func MustNewClient(baseURL string, opts ...ClientOption) *Client {
+	c, err := NewClient(baseURL, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// normalizeURL validates the node url, defaults its scheme to https, and trims the trailing slash.
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("empty url")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid url %q: unsupported scheme %q", raw, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid url %q: empty host", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid url %q: unexpected query or fragment", raw)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// newHTTPClient creates the default http client with a keep-alive transport.
+func (c *Client) newHTTPClient() *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          c.maxIdleConnsPerHost * 4,
+		MaxIdleConnsPerHost:   c.maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       c.tlsConfig,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   c.timeout,
+	}
+}
+
+// BaseURL returns the node's base url, which is the first node of a pool.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// HTTPError is returned when the node responds with a non-200 status.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, strings.TrimSpace(e.Body))
+}
+
+// httpGet sends GET request to path, and decodes json response into result.
+func (c *Client) httpGet(ctx context.Context, path string, query url.Values, result interface{}) error {
+	return c.httpDo(ctx, http.MethodGet, path, query, nil, result)
+}
+
+// httpPost sends POST request with json encoded body to path, and decodes json response into result.
+func (c *Client) httpPost(ctx context.Context, path string, query url.Values, body interface{}, result interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.httpDo(ctx, http.MethodPost, path, query, data, result)
+}
+
+// httpDo sends request to nodes in turn, until one responds without a connection error or 5xx status.
+func (c *Client) httpDo(ctx context.Context, method, path string, query url.Values, body []byte, result interface{}) error {
+	var err error
+	for _, n := range c.pickNodes() {
+		err = c.httpDoNode(ctx, n, method, path, query, body, result)
+		if isTooManyRequests(err) {
+			err = c.backoff(ctx, func() error {
+				return c.httpDoNode(ctx, n, method, path, query, body, result)
+			})
+		}
+		if !isNodeFailure(ctx, err) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		c.logWarn("node failed, retrying next", "url", n.url, "err", err)
+	}
+	return err
+}
+
+// isTooManyRequests returns whether err is a 429 response of a throttling node.
+func isTooManyRequests(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests
+}
+
+// backoff retries a throttled request with exponential delays, until it's not throttled.
+// It returns the last error after maxThrottleRetries retries.
+func (c *Client) backoff(ctx context.Context, do func() error) error {
+	delay := throttleBackoff
+	var err error
+	for i := 0; i < maxThrottleRetries; i++ {
+		c.logWarn("throttled by node, backing off", "delay", delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		if err = do(); !isTooManyRequests(err) {
+			return err
+		}
+		delay *= 2
+	}
+	return err
+}
+
+// httpDoNode sends request to a single node, and records the node health.
+func (c *Client) httpDoNode(ctx context.Context, n *node, method, path string, query url.Values, body []byte, result interface{}) error {
+	err := c.send(ctx, n.url, method, path, query, body, result, nil)
+	n.record(ctx, err)
+	return err
+}
+
+// send sends request to the node at baseURL, and stores the response header into header if not nil.
+func (c *Client) send(ctx context.Context, baseURL, method, path string, query url.Values, body []byte, result interface{}, header *http.Header) (err error) {
+	u := baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	var status int
+	done := c.traceRequest(method, u)
+	defer func() { done(status, err) }()
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	status = res.StatusCode
+	if header != nil {
+		*header = res.Header
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return &HTTPError{StatusCode: res.StatusCode, Body: string(data)}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"meter-go/meter"
+)
+
+const testBestNumber = 1000
+
+// newTestNode starts a node serving blocks 0 to testBestNumber, one every 2 seconds,
+// and counts the requests it serves.
+func newTestNode(t *testing.T) (*httptest.Server, *int64) {
+	var count int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&count, 1)
+		rev := strings.TrimPrefix(r.URL.Path, "/blocks/")
+		if rev == r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+		num := uint64(testBestNumber)
+		if rev != "best" {
+			n, err := strconv.ParseUint(rev, 10, 32)
+			if err != nil || n > testBestNumber {
+				w.Write([]byte("null"))
+				return
+			}
+			num = n
+		}
+		var id meter.Bytes32
+		id[0], id[1], id[2], id[3] = byte(num>>24), byte(num>>16), byte(num>>8), byte(num)
+		id[31] = 0x52
+		json.NewEncoder(w).Encode(&Block{Number: uint32(num), ID: id, Timestamp: 1600000000 + num*2})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &count
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	srv1, count1 := newTestNode(t)
+	srv2, count2 := newTestNode(t)
+	c, err := NewClientPool([]string{srv1.URL, srv2.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 16; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			params, err := c.Params(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if params.ChainTag != 0x52 || params.BlockInterval != 2*time.Second {
+				errs <- fmt.Errorf("unexpected params %+v", params)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			var b *Block
+			if err := c.httpGet(ctx, "/blocks/"+strconv.Itoa(i), nil, &b); err != nil {
+				errs <- err
+				return
+			}
+			if b == nil || b.Number != uint32(i) {
+				errs <- fmt.Errorf("unexpected block %+v, want %d", b, i)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetBestBlock(ctx); err != nil {
+				errs <- err
+			}
+			c.NodeHealth()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if atomic.LoadInt64(count1) == 0 || atomic.LoadInt64(count2) == 0 {
+		t.Errorf("requests not spread over the pool: %d, %d", *count1, *count2)
+	}
+	for _, h := range c.NodeHealth() {
+		if h.ConsecutiveFailures != 0 {
+			t.Errorf("node %v: %d failures", h.URL, h.ConsecutiveFailures)
+		}
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	srv, _ := newTestNode(t)
+	tests := []struct {
+		rps       float64
+		burst     int
+		limited   bool
+		wantBurst int
+	}{
+		{100, 5, true, 5},
+		{100, 0, true, 1},
+		{100, -3, true, 1},
+		{0, 5, false, 0},
+		{-1, 0, false, 0},
+	}
+	for _, tt := range tests {
+		c, err := NewClient(srv.URL, WithRateLimit(tt.rps, tt.burst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if limited := c.limiter != nil; limited != tt.limited {
+			t.Errorf("rps %v burst %d: limited = %v, want %v", tt.rps, tt.burst, limited, tt.limited)
+			continue
+		}
+		if tt.limited && c.limiter.Burst() != tt.wantBurst {
+			t.Errorf("rps %v burst %d: burst = %d, want %d", tt.rps, tt.burst, c.limiter.Burst(), tt.wantBurst)
+		}
+		for i := 0; i < 3; i++ {
+			if _, err := c.GetBestBlock(context.Background()); err != nil {
+				t.Errorf("rps %v burst %d: %v", tt.rps, tt.burst, err)
+			}
+		}
+	}
+}
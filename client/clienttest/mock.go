@@ -0,0 +1,344 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package clienttest provides an in-memory client.Backend for testing code offline.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"meter-go/client"
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// MockBackend is an in-memory client.Backend with canned states.
+// States are set by the Add/Set methods, and broadcasts are programmed by ExpectSend.
+// Missing blocks, txs and receipts are returned as nil, like the node does.
+// It's safe for concurrent use.
+type MockBackend struct {
+	lock         sync.Mutex
+	blocks       map[string]*client.Block // by number and id
+	best         *client.Block
+	accounts     map[meter.Address]*client.Account
+	code         map[meter.Address][]byte
+	storage      map[meter.Address]map[meter.Bytes32]meter.Bytes32
+	txs          map[meter.Bytes32]*client.ClientTransaction
+	receipts     map[meter.Bytes32]*client.Receipt
+	events       []*client.Event
+	transfers    []*client.Transfer
+	inspect      func(clauses []*tx.Clause, caller *meter.Address) ([]*client.CallResult, error)
+	baseGasPrice *big.Int
+	expectations []*SendExpectation
+	sent         []*tx.Transaction
+}
+
+var _ client.Backend = (*MockBackend)(nil)
+
+// NewMockBackend creates an empty mock backend.
+func NewMockBackend() *MockBackend {
+	return &MockBackend{
+		blocks:   make(map[string]*client.Block),
+		accounts: make(map[meter.Address]*client.Account),
+		code:     make(map[meter.Address][]byte),
+		storage:  make(map[meter.Address]map[meter.Bytes32]meter.Bytes32),
+		txs:      make(map[meter.Bytes32]*client.ClientTransaction),
+		receipts: make(map[meter.Bytes32]*client.Receipt),
+	}
+}
+
+// SendExpectation is an expected broadcast, see MockBackend.ExpectSend.
+type SendExpectation struct {
+	t   *tx.Transaction
+	id  *meter.Bytes32
+	err error
+}
+
+// Return makes the broadcast return id. Without Return, the id of the tx is returned.
+func (e *SendExpectation) Return(id meter.Bytes32) *SendExpectation {
+	e.id = &id
+	return e
+}
+
+// ReturnError makes the broadcast fail with err, e.g. a *client.RejectionError.
+func (e *SendExpectation) ReturnError(err error) *SendExpectation {
+	e.err = err
+	return e
+}
+
+// ExpectSend expects a broadcast of t, or any tx if t is nil.
+// Expectations are consumed in order, and an unexpected broadcast fails.
+func (m *MockBackend) ExpectSend(t *tx.Transaction) *SendExpectation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	e := &SendExpectation{t: t}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Sent returns the txs broadcast successfully, in order.
+func (m *MockBackend) Sent() []*tx.Transaction {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*tx.Transaction(nil), m.sent...)
+}
+
+// AddBlock adds a block, which becomes the best block if it's the highest.
+func (m *MockBackend) AddBlock(b *client.Block) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.blocks[strconv.FormatUint(uint64(b.Number), 10)] = b
+	m.blocks[b.ID.String()] = b
+	if m.best == nil || b.Number >= m.best.Number {
+		m.best = b
+	}
+}
+
+// SetAccount sets the account state, which is the same at every revision.
+func (m *MockBackend) SetAccount(addr meter.Address, acc *client.Account) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.accounts[addr] = acc
+}
+
+// SetCode sets the code of account.
+func (m *MockBackend) SetCode(addr meter.Address, code []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.code[addr] = append([]byte(nil), code...)
+}
+
+// SetStorage sets a storage slot of account.
+func (m *MockBackend) SetStorage(addr meter.Address, key, value meter.Bytes32) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.storage[addr] == nil {
+		m.storage[addr] = make(map[meter.Bytes32]meter.Bytes32)
+	}
+	m.storage[addr][key] = value
+}
+
+// AddTransaction adds a tx, and its receipt if r is not nil.
+func (m *MockBackend) AddTransaction(t *client.ClientTransaction, r *client.Receipt) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.txs[t.ID] = t
+	if r != nil {
+		m.receipts[t.ID] = r
+	}
+}
+
+// SetReceipt sets the receipt of tx, e.g. to mine a tx broadcast before.
+func (m *MockBackend) SetReceipt(id meter.Bytes32, r *client.Receipt) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.receipts[id] = r
+}
+
+// SetEvents sets the events returned by FilterEvents, regardless of criteria.
+func (m *MockBackend) SetEvents(events []*client.Event) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = events
+}
+
+// SetTransfers sets the transfers returned by FilterTransfers, regardless of criteria.
+func (m *MockBackend) SetTransfers(transfers []*client.Transfer) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.transfers = transfers
+}
+
+// SetInspect sets the func simulating clauses for Inspect.
+// Without it, every clause succeeds with no output and no gas used.
+func (m *MockBackend) SetInspect(fn func(clauses []*tx.Clause, caller *meter.Address) ([]*client.CallResult, error)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.inspect = fn
+}
+
+// SetBaseGasPrice sets the base gas price returned by BaseGasPrice, which is zero by default.
+func (m *MockBackend) SetBaseGasPrice(price *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.baseGasPrice = new(big.Int).Set(price)
+}
+
+// GetBlock implements client.Backend.
+func (m *MockBackend) GetBlock(ctx context.Context, rev client.Revision) (*client.Block, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if rev.String() == client.RevisionBest().String() {
+		return m.best, nil
+	}
+	return m.blocks[rev.String()], nil
+}
+
+// GetBlockExpanded implements client.Backend.
+// The block carries the txs of its Transactions added by AddTransaction, along with their receipts,
+// while other txs are left out.
+func (m *MockBackend) GetBlockExpanded(ctx context.Context, rev client.Revision) (*client.ExpandedBlock, error) {
+	b, err := m.GetBlock(ctx, rev)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	expanded := &client.ExpandedBlock{Block: *b}
+	for _, id := range b.Transactions {
+		t, ok := m.txs[id]
+		if !ok {
+			continue
+		}
+		bt := &client.BlockTransaction{ClientTransaction: *t}
+		if r := m.receipts[id]; r != nil {
+			bt.GasUsed = r.GasUsed
+			bt.GasPayer = r.GasPayer
+			bt.Paid = r.Paid
+			bt.Reward = r.Reward
+			bt.Reverted = r.Reverted
+			bt.Outputs = r.Outputs
+		}
+		expanded.Transactions = append(expanded.Transactions, bt)
+	}
+	return expanded, nil
+}
+
+// GetBestBlock implements client.Backend.
+func (m *MockBackend) GetBestBlock(ctx context.Context) (*client.Block, error) {
+	return m.GetBlock(ctx, client.RevisionBest())
+}
+
+// GetAccount implements client.Backend. Unknown accounts are empty.
+func (m *MockBackend) GetAccount(ctx context.Context, addr meter.Address, rev client.Revision) (*client.Account, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if acc, ok := m.accounts[addr]; ok {
+		cpy := *acc
+		return &cpy, nil
+	}
+	return &client.Account{
+		Balance:      new(big.Int),
+		Energy:       new(big.Int),
+		BoundBalance: new(big.Int),
+		BoundEnergy:  new(big.Int),
+	}, nil
+}
+
+// GetAccounts implements client.Backend.
+func (m *MockBackend) GetAccounts(ctx context.Context, addrs []meter.Address, rev client.Revision) ([]*client.Account, error) {
+	accs := make([]*client.Account, 0, len(addrs))
+	for _, addr := range addrs {
+		acc, err := m.GetAccount(ctx, addr, rev)
+		if err != nil {
+			return nil, err
+		}
+		accs = append(accs, acc)
+	}
+	return accs, nil
+}
+
+// GetCode implements client.Backend.
+func (m *MockBackend) GetCode(ctx context.Context, addr meter.Address, rev client.Revision) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]byte{}, m.code[addr]...), nil
+}
+
+// GetStorage implements client.Backend.
+func (m *MockBackend) GetStorage(ctx context.Context, addr meter.Address, key meter.Bytes32, rev client.Revision) (meter.Bytes32, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.storage[addr][key], nil
+}
+
+// GetTransaction implements client.Backend.
+func (m *MockBackend) GetTransaction(ctx context.Context, id meter.Bytes32, opts client.GetTxOptions) (*client.ClientTransaction, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.txs[id], nil
+}
+
+// GetTransactionReceipt implements client.Backend.
+func (m *MockBackend) GetTransactionReceipt(ctx context.Context, id meter.Bytes32) (*client.Receipt, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.receipts[id], nil
+}
+
+// SendTransaction implements client.Backend, consuming the next expectation.
+func (m *MockBackend) SendTransaction(ctx context.Context, t *tx.Transaction) (meter.Bytes32, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.expectations) == 0 {
+		return meter.Bytes32{}, errors.New("clienttest: unexpected send")
+	}
+	e := m.expectations[0]
+	if e.t != nil && e.t.ID() != t.ID() {
+		return meter.Bytes32{}, fmt.Errorf("clienttest: unexpected send of tx %v, expecting %v", t.ID(), e.t.ID())
+	}
+	m.expectations = m.expectations[1:]
+	if e.err != nil {
+		return meter.Bytes32{}, e.err
+	}
+	m.sent = append(m.sent, t)
+	if e.id != nil {
+		return *e.id, nil
+	}
+	return t.ID(), nil
+}
+
+// SendRaw implements client.Backend, decoding the raw tx and sending it by SendTransaction.
+func (m *MockBackend) SendRaw(ctx context.Context, raw string) (meter.Bytes32, error) {
+	t, err := tx.Decode(raw)
+	if err != nil {
+		return meter.Bytes32{}, fmt.Errorf("invalid raw tx: %w", err)
+	}
+	return m.SendTransaction(ctx, t)
+}
+
+// Inspect implements client.Backend.
+func (m *MockBackend) Inspect(ctx context.Context, clauses []*tx.Clause, caller *meter.Address, rev client.Revision) ([]*client.CallResult, error) {
+	m.lock.Lock()
+	fn := m.inspect
+	m.lock.Unlock()
+	if fn != nil {
+		return fn(clauses, caller)
+	}
+	results := make([]*client.CallResult, 0, len(clauses))
+	for range clauses {
+		results = append(results, &client.CallResult{})
+	}
+	return results, nil
+}
+
+// BaseGasPrice implements client.Backend, see SetBaseGasPrice.
+func (m *MockBackend) BaseGasPrice(ctx context.Context, rev client.Revision) (*big.Int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.baseGasPrice == nil {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(m.baseGasPrice), nil
+}
+
+// FilterEvents implements client.Backend.
+func (m *MockBackend) FilterEvents(ctx context.Context, criteria client.EventCriteria) ([]*client.Event, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*client.Event(nil), m.events...), nil
+}
+
+// FilterTransfers implements client.Backend.
+func (m *MockBackend) FilterTransfers(ctx context.Context, criteria client.TransferCriteria) ([]*client.Transfer, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*client.Transfer(nil), m.transfers...), nil
+}
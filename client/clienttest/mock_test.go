@@ -0,0 +1,300 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package clienttest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"meter-go/client"
+	"meter-go/client/clienttest"
+	"meter-go/meter"
+	"meter-go/testvectors"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const chainTag = 0x52
+
+var (
+	recipient = meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	origin    = testvectors.Vectors[0].Origin
+)
+
+func newBlock(n uint32) *client.Block {
+	var id meter.Bytes32
+	id[0], id[1], id[2], id[3] = byte(n>>24), byte(n>>16), byte(n>>8), byte(n)
+	id[31] = chainTag
+	return &client.Block{Number: n, ID: id}
+}
+
+// newMock returns a mock with blocks 0 to best.
+func newMock(best uint32) *clienttest.MockBackend {
+	m := clienttest.NewMockBackend()
+	for n := uint32(0); n <= best; n++ {
+		m.AddBlock(newBlock(n))
+	}
+	return m
+}
+
+func newSignedTx(t *testing.T, blockRef uint32, build func(*tx.Builder)) *tx.Transaction {
+	b := new(tx.Builder).
+		ChainTag(chainTag).
+		BlockRef(tx.NewBlockRef(blockRef)).
+		Expiration(10).
+		Gas(21000).
+		Clause(tx.TransferMTR(recipient, big.NewInt(1)))
+	if build != nil {
+		build(b)
+	}
+	key, err := crypto.HexToECDSA(testvectors.Vectors[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := b.Build().SignWith(tx.NewPrivateKeySigner(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestExpectSend(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(1)
+	t1 := newSignedTx(t, 1, nil)
+	t2 := newSignedTx(t, 1, func(b *tx.Builder) { b.Nonce(2) })
+
+	if _, err := m.SendTransaction(ctx, t1); err == nil {
+		t.Fatal("expected error for unexpected send")
+	}
+
+	rejection := &client.RejectionError{Reason: client.RejectExpired, Message: "tx expired"}
+	m.ExpectSend(t1)
+	m.ExpectSend(nil).ReturnError(rejection)
+	override := meter.Bytes32{1}
+	m.ExpectSend(nil).Return(override)
+
+	if _, err := m.SendTransaction(ctx, t2); err == nil {
+		t.Fatal("expected error for send of other tx")
+	}
+	id, err := m.SendTransaction(ctx, t1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != t1.ID() {
+		t.Fatalf("got id %v, want %v", id, t1.ID())
+	}
+	if _, err := m.SendTransaction(ctx, t2); !errors.Is(err, rejection) {
+		t.Fatalf("got %v, want rejection", err)
+	}
+	raw, err := t2.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := m.SendRaw(ctx, hexutil.Encode(raw)); err != nil || id != override {
+		t.Fatalf("got %v, %v, want %v", id, err, override)
+	}
+	if _, err := m.SendRaw(ctx, "0x01"); err == nil {
+		t.Fatal("expected error for invalid raw tx")
+	}
+
+	sent := m.Sent()
+	if len(sent) != 2 || sent[0].ID() != t1.ID() || sent[1].ID() != t2.ID() {
+		t.Fatalf("unexpected sent txs %v", sent)
+	}
+}
+
+func TestStates(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(2)
+
+	best, err := m.GetBestBlock(ctx)
+	if err != nil || best == nil || best.Number != 2 {
+		t.Fatalf("got best %v, %v", best, err)
+	}
+	if b, _ := m.GetBlock(ctx, client.RevisionID(newBlock(1).ID)); b == nil || b.Number != 1 {
+		t.Fatalf("got block %v by id", b)
+	}
+	if b, _ := m.GetBlock(ctx, client.RevisionNumber(3)); b != nil {
+		t.Fatalf("got missing block %v", b)
+	}
+
+	m.SetAccount(origin, &client.Account{Balance: big.NewInt(1), Energy: big.NewInt(2)})
+	accs, err := m.GetAccounts(ctx, []meter.Address{origin, recipient}, client.RevisionBest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accs[0].Energy.Int64() != 2 || accs[1].Energy.Sign() != 0 || accs[1].Balance == nil {
+		t.Fatalf("unexpected accounts %+v, %+v", accs[0], accs[1])
+	}
+
+	key := meter.Bytes32{1}
+	m.SetStorage(recipient, key, meter.Bytes32{2})
+	m.SetCode(recipient, []byte{0x60})
+	if v, _ := m.GetStorage(ctx, recipient, key, client.RevisionBest()); v != (meter.Bytes32{2}) {
+		t.Fatalf("got storage %v", v)
+	}
+	if code, _ := m.GetCode(ctx, recipient, client.RevisionBest()); len(code) != 1 {
+		t.Fatalf("got code %x", code)
+	}
+
+	if price, _ := m.BaseGasPrice(ctx, client.RevisionBest()); price.Sign() != 0 {
+		t.Fatalf("got base gas price %v, want 0", price)
+	}
+	m.SetBaseGasPrice(big.NewInt(500))
+	if price, _ := m.BaseGasPrice(ctx, client.RevisionBest()); price.Int64() != 500 {
+		t.Fatalf("got base gas price %v, want 500", price)
+	}
+}
+
+func TestGetBlockExpanded(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(0)
+	trx := newSignedTx(t, 0, nil)
+	b := newBlock(1)
+	b.Transactions = []meter.Bytes32{trx.ID(), {0xff}}
+	m.AddBlock(b)
+	m.AddTransaction(&client.ClientTransaction{ID: trx.ID(), Origin: origin}, &client.Receipt{GasUsed: 21000, Reverted: true})
+
+	expanded, err := m.GetBlockExpanded(ctx, client.RevisionBest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expanded.Number != 1 || len(expanded.Transactions) != 1 {
+		t.Fatalf("unexpected expanded block %+v", expanded)
+	}
+	if bt := expanded.Transactions[0]; bt.ID != trx.ID() || bt.GasUsed != 21000 || !bt.Reverted {
+		t.Fatalf("unexpected block tx %+v", bt)
+	}
+}
+
+func TestValidateTransaction(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(5)
+	m.SetBaseGasPrice(big.NewInt(1e9))
+	trx := newSignedTx(t, 5, nil)
+
+	// no energy to pay gas
+	var verr *client.ValidationError
+	if err := client.ValidateTransaction(ctx, m, trx); !errors.As(err, &verr) {
+		t.Fatalf("got %v, want validation error", err)
+	}
+
+	m.SetAccount(origin, &client.Account{Balance: new(big.Int), Energy: big.NewInt(1e18)})
+	if err := client.ValidateTransaction(ctx, m, trx); err != nil {
+		t.Fatal(err)
+	}
+
+	expired := newSignedTx(t, 0, func(b *tx.Builder) { b.Expiration(1) })
+	if err := client.ValidateTransaction(ctx, m, expired); !errors.As(err, &verr) {
+		t.Fatalf("got %v, want validation error", err)
+	}
+}
+
+func TestCanAfford(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(1)
+	trx := newSignedTx(t, 1, func(b *tx.Builder) {
+		b.Clause(tx.TransferMTRG(recipient, big.NewInt(5)))
+	})
+	base := big.NewInt(1)
+
+	m.SetAccount(origin, &client.Account{Balance: big.NewInt(4), Energy: big.NewInt(1e9)})
+	ok, report, err := client.CanAfford(ctx, m, trx, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || report == nil || len(report.Shortfalls) != 1 {
+		t.Fatalf("got %v, %v, want a MTRG shortfall", ok, report)
+	}
+	if s := report.Shortfalls[0]; s.Token != tx.MeterGovToken || s.Amount().Int64() != 1 {
+		t.Fatalf("unexpected shortfall %+v", s)
+	}
+
+	m.SetAccount(origin, &client.Account{Balance: big.NewInt(5), Energy: big.NewInt(1e9)})
+	if ok, report, err := client.CanAfford(ctx, m, trx, base); err != nil || !ok {
+		t.Fatalf("got %v, %v, %v, want affordable", ok, report, err)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(1)
+	trx := newSignedTx(t, 1, nil)
+
+	m.ExpectSend(trx)
+	m.SetReceipt(trx.ID(), &client.Receipt{GasUsed: 21000})
+	h, err := client.Submit(ctx, m, trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := h.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GasUsed != 21000 {
+		t.Fatalf("unexpected receipt %+v", r)
+	}
+
+	dep := meter.Bytes32{0xde}
+	m.SetReceipt(dep, &client.Receipt{Reverted: true})
+	dependent := newSignedTx(t, 1, func(b *tx.Builder) { b.DependsOn(&dep) })
+	if _, err := client.Submit(ctx, m, dependent); !errors.Is(err, client.ErrDependencyReverted) {
+		t.Fatalf("got %v, want ErrDependencyReverted", err)
+	}
+	if len(m.Sent()) != 1 {
+		t.Fatal("tx with reverted dependency was sent")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	m := newMock(1)
+	mined := newSignedTx(t, 1, nil)
+	expiring := newSignedTx(t, 1, func(b *tx.Builder) { b.Nonce(2).Expiration(1) })
+
+	var gotMined, gotExpired []meter.Bytes32
+	tr := client.NewTracker(m, client.TrackerCallbacks{
+		Mined:   func(t *tx.Transaction, r *client.Receipt) { gotMined = append(gotMined, t.ID()) },
+		Expired: func(t *tx.Transaction) { gotExpired = append(gotExpired, t.ID()) },
+	})
+	for _, trx := range []*tx.Transaction{mined, expiring} {
+		if err := tr.Add(trx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unsigned := new(tx.Builder).ChainTag(chainTag).Gas(21000).Build()
+	if err := tr.Add(unsigned); err == nil {
+		t.Fatal("expected error adding unsigned tx")
+	}
+
+	if err := tr.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(gotMined)+len(gotExpired) != 0 || len(tr.Pending()) != 2 {
+		t.Fatal("txs reported before mined or expired")
+	}
+
+	m.SetReceipt(mined.ID(), &client.Receipt{})
+	m.AddBlock(newBlock(2))
+	m.AddBlock(newBlock(3))
+	if err := tr.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(gotMined) != 1 || gotMined[0] != mined.ID() {
+		t.Fatalf("got mined %v", gotMined)
+	}
+	if len(gotExpired) != 1 || gotExpired[0] != expiring.ID() {
+		t.Fatalf("got expired %v", gotExpired)
+	}
+	if len(tr.Pending()) != 0 {
+		t.Fatalf("still pending %v", tr.Pending())
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"meter-go/meter"
+)
+
+// ErrDependencyReverted is returned by Submit for a tx depending on a reverted tx,
+// which can never be mined.
+var ErrDependencyReverted = errors.New("dependency reverted")
+
+// DepStatus is the status of a depended tx.
+type DepStatus int
+
+// Statuses of a depended tx.
+const (
+	DepPending   DepStatus = iota // not mined yet, or unknown to the node
+	DepSucceeded                  // mined and not reverted
+	DepReverted                   // mined but reverted, so dependent txs are invalid
+)
+
+// String implements the stringer interface.
+func (s DepStatus) String() string {
+	switch s {
+	case DepSucceeded:
+		return "succeeded"
+	case DepReverted:
+		return "reverted"
+	}
+	return "pending"
+}
+
+// DependencyStatus returns the status of the depended tx by its receipt.
+func (c *Client) DependencyStatus(ctx context.Context, dep meter.Bytes32) (DepStatus, error) {
+	return DependencyStatus(ctx, c, dep)
+}
+
+// DependencyStatus is Client.DependencyStatus against any backend.
+func DependencyStatus(ctx context.Context, b Backend, dep meter.Bytes32) (DepStatus, error) {
+	r, err := b.GetTransactionReceipt(ctx, dep)
+	if err != nil {
+		return DepPending, err
+	}
+	switch {
+	case r == nil:
+		return DepPending, nil
+	case r.Reverted:
+		return DepReverted, nil
+	}
+	return DepSucceeded, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxTopics is the max count of topics of an event.
+const maxTopics = 4
+
+// LogMeta describes where a log is emitted.
+type LogMeta struct {
+	BlockID        meter.Bytes32 `json:"blockID"`
+	BlockNumber    uint32        `json:"blockNumber"`
+	BlockTimestamp uint64        `json:"blockTimestamp"`
+	TxID           meter.Bytes32 `json:"txID"`
+	TxOrigin       meter.Address `json:"txOrigin"`
+	ClauseIndex    uint32        `json:"clauseIndex"`
+}
+
+// Event is an event log emitted by a contract.
+// Meta is nil for events embedded in a receipt.
+type Event struct {
+	Address meter.Address   `json:"address"`
+	Topics  []meter.Bytes32 `json:"topics"`
+	Data    hexutil.Bytes   `json:"data"`
+	Meta    *LogMeta        `json:"meta,omitempty"`
+
+	// Obsolete is only set on subscribed events, which marks an event that has been
+	// reverted by a chain fork.
+	Obsolete bool `json:"obsolete,omitempty"`
+}
+
+// EventFilter filters subscribed events.
+// A nil Address or topic matches any value.
+type EventFilter struct {
+	Address *meter.Address
+	Topics  []*meter.Bytes32
+}
+
+// SubscribeEvents streams event logs matching filter from the node's websocket endpoint, until ctx is canceled.
+// On reconnection, events are resumed from the block of the last received event, so a few events
+// may be delivered more than once.
+func (c *Client) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan *Event, <-chan error, error) {
+	if len(filter.Topics) > maxTopics {
+		return nil, nil, errors.New("too many topics")
+	}
+	query := url.Values{}
+	if filter.Address != nil {
+		query.Set("addr", filter.Address.String())
+	}
+	for i, topic := range filter.Topics {
+		if topic != nil {
+			query.Set("t"+strconv.Itoa(i), topic.String())
+		}
+	}
+
+	var (
+		events = make(chan *Event)
+		// the node resumes after pos, so it is moved to a block only when
+		// all events of the block are received.
+		curBlock, prevBlock meter.Bytes32
+	)
+	errs, err := c.subscribe(ctx, "/subscriptions/event", query, func(data []byte) (string, error) {
+		var ev Event
+		if err := json.Unmarshal(data, &ev); err != nil {
+			return "", err
+		}
+		if ev.Meta == nil {
+			return "", errors.New("missing event meta")
+		}
+		select {
+		case events <- &ev:
+		case <-ctx.Done():
+		}
+		if ev.Meta.BlockID != curBlock {
+			prevBlock, curBlock = curBlock, ev.Meta.BlockID
+		}
+		if prevBlock == (meter.Bytes32{}) {
+			return "", nil
+		}
+		return prevBlock.String(), nil
+	}, func() { close(events) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, errs, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"net/url"
+	"strings"
+
+	"meter-go/meter"
+)
+
+const (
+	mainnetExplorerURL = "https://scan.meter.io"
+	testnetExplorerURL = "https://scan-warringstakes.meter.io"
+)
+
+// guessExplorerURL derives the explorer from well-known node hosts.
+// It returns empty string for unknown hosts.
+func guessExplorerURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+	if host != "meter.io" && !strings.HasSuffix(host, ".meter.io") {
+		return ""
+	}
+	if strings.Contains(host, "warringstakes") {
+		return testnetExplorerURL
+	}
+	return mainnetExplorerURL
+}
+
+// ExplorerTxURL returns the explorer link of tx.
+// It returns empty string if the explorer is unknown, see WithExplorer.
+func (c *Client) ExplorerTxURL(id meter.Bytes32) string {
+	if c.explorerURL == "" {
+		return ""
+	}
+	return c.explorerURL + "/tx/" + id.String()
+}
+
+// ExplorerAddressURL returns the explorer link of account, using the checksummed address.
+// It returns empty string if the explorer is unknown, see WithExplorer.
+func (c *Client) ExplorerAddressURL(addr meter.Address) string {
+	if c.explorerURL == "" {
+		return ""
+	}
+	return c.explorerURL + "/address/" + addr.ChecksumString()
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultStaleThreshold is how far the best block can be behind wall-clock time before
+// the node is considered out of sync.
+const DefaultStaleThreshold = time.Minute
+
+// versionHeaders are the response headers carrying the node version, if the node sets one.
+var versionHeaders = []string{"X-Meterest-Ver", "X-Thorest-Ver"}
+
+// HealthStatus is the result of probing a node.
+// It's named apart from NodeHealth, which is the request statistics of a node in a pool.
+type HealthStatus struct {
+	URL           string
+	BestNumber    uint32
+	BestTimestamp time.Time
+	Behind        time.Duration // how far the best block is behind wall-clock time
+	Stale         bool          // Behind exceeds DefaultStaleThreshold
+	Version       string        // empty if the node doesn't report its version
+	Network       Network       // NetworkUnknown for a custom network
+}
+
+// Health probes the node by its best block, and reports whether it's synced.
+// For a client pool, the first node responding is probed.
+func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
+	var err error
+	for _, n := range c.pickNodes() {
+		var status *HealthStatus
+		status, err = c.probe(ctx, n)
+		if err == nil {
+			return status, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (c *Client) probe(ctx context.Context, n *node) (*HealthStatus, error) {
+	var (
+		best   *Block
+		header http.Header
+	)
+	err := c.send(ctx, n.url, http.MethodGet, "/blocks/best", nil, nil, &best, &header)
+	n.record(ctx, err)
+	if err != nil {
+		return nil, err
+	}
+	if best == nil {
+		return nil, errors.New("best block not found")
+	}
+
+	ts := time.Unix(int64(best.Timestamp), 0)
+	behind := time.Since(ts)
+	if behind < 0 {
+		behind = 0
+	}
+	status := &HealthStatus{
+		URL:           n.url,
+		BestNumber:    best.Number,
+		BestTimestamp: ts,
+		Behind:        behind,
+		Stale:         behind > DefaultStaleThreshold,
+	}
+	for _, h := range versionHeaders {
+		if v := header.Get(h); v != "" {
+			status.Version = v
+			break
+		}
+	}
+	if params, err := c.Params(ctx); err == nil {
+		for network, info := range networks {
+			if info.chainTag == params.ChainTag {
+				status.Network = network
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import "time"
+
+// Logger is the logger of client. The ctx is alternating keys and values, e.g. "url", u, "status", 200.
+type Logger interface {
+	Debug(msg string, ctx ...interface{})
+	Warn(msg string, ctx ...interface{})
+}
+
+// RequestInfo describes a http request about to be sent.
+type RequestInfo struct {
+	Method string
+	URL    string
+}
+
+// ResponseInfo describes the outcome of a http request.
+type ResponseInfo struct {
+	Method     string
+	URL        string
+	StatusCode int // 0 if no response received
+	Duration   time.Duration
+	Err        error
+}
+
+// WithLogger set the logger. The client logs nothing by default.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// OnRequest set the hook called before each http request, e.g. for metrics.
+func OnRequest(hook func(RequestInfo)) ClientOption {
+	return func(c *Client) {
+		c.onRequest = hook
+	}
+}
+
+// OnResponse set the hook called after each http request, e.g. for metrics.
+func OnResponse(hook func(ResponseInfo)) ClientOption {
+	return func(c *Client) {
+		c.onResponse = hook
+	}
+}
+
+func (c *Client) logDebug(msg string, ctx ...interface{}) {
+	if c.logger != nil {
+		c.logger.Debug(msg, ctx...)
+	}
+}
+
+func (c *Client) logWarn(msg string, ctx ...interface{}) {
+	if c.logger != nil {
+		c.logger.Warn(msg, ctx...)
+	}
+}
+
+// traceRequest calls the request hook and logs the start, and returns a func to trace the end.
+func (c *Client) traceRequest(method, u string) func(status int, err error) {
+	if c.onRequest != nil {
+		c.onRequest(RequestInfo{Method: method, URL: u})
+	}
+	c.logDebug("request start", "method", method, "url", u)
+	start := time.Now()
+	return func(status int, err error) {
+		elapsed := time.Since(start)
+		if c.onResponse != nil {
+			c.onResponse(ResponseInfo{Method: method, URL: u, StatusCode: status, Duration: elapsed, Err: err})
+		}
+		if err != nil {
+			c.logDebug("request failed", "method", method, "url", u, "status", status, "elapsed", elapsed, "err", err)
+		} else {
+			c.logDebug("request end", "method", method, "url", u, "status", status, "elapsed", elapsed)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"meter-go/meter"
+)
+
+// Order is the order of filtered logs.
+type Order string
+
+// Log orders.
+const (
+	OrderAsc  Order = "asc"
+	OrderDesc Order = "desc"
+)
+
+// EventCriteria describes a historical event log query.
+type EventCriteria struct {
+	FromBlock uint32
+	ToBlock   uint32
+	Order     Order  // asc if empty
+	Offset    uint64 // offset of pagination
+	Limit     uint64 // limit of pagination, no limit if 0
+
+	// Filters are ORed, an empty filter set matches all events.
+	Filters []EventFilter
+}
+
+type logRange struct {
+	Unit string `json:"unit"`
+	From uint32 `json:"from"`
+	To   uint32 `json:"to"`
+}
+
+type logOptions struct {
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type eventCriteria struct {
+	Address *meter.Address `json:"address,omitempty"`
+	Topic0  *meter.Bytes32 `json:"topic0,omitempty"`
+	Topic1  *meter.Bytes32 `json:"topic1,omitempty"`
+	Topic2  *meter.Bytes32 `json:"topic2,omitempty"`
+	Topic3  *meter.Bytes32 `json:"topic3,omitempty"`
+}
+
+type eventQuery struct {
+	Range       *logRange       `json:"range"`
+	Options     *logOptions     `json:"options,omitempty"`
+	CriteriaSet []eventCriteria `json:"criteriaSet,omitempty"`
+	Order       Order           `json:"order"`
+}
+
+// FilterEvents queries historical event logs.
+// The block range is not limited here, a query the node refuses to serve fails with its *HTTPError.
+func (c *Client) FilterEvents(ctx context.Context, criteria EventCriteria) ([]*Event, error) {
+	r, err := newLogRange(criteria.FromBlock, criteria.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	query := eventQuery{
+		Range:   r,
+		Options: newLogOptions(criteria.Offset, criteria.Limit),
+		Order:   orderOrDefault(criteria.Order),
+	}
+	for _, f := range criteria.Filters {
+		if len(f.Topics) > maxTopics {
+			return nil, errors.New("too many topics")
+		}
+		var ec eventCriteria
+		ec.Address = f.Address
+		topics := []**meter.Bytes32{&ec.Topic0, &ec.Topic1, &ec.Topic2, &ec.Topic3}
+		for i, topic := range f.Topics {
+			*topics[i] = topic
+		}
+		query.CriteriaSet = append(query.CriteriaSet, ec)
+	}
+
+	var events []*Event
+	if err := c.httpPost(ctx, "/logs/event", nil, &query, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func newLogRange(from, to uint32) (*logRange, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	return &logRange{Unit: "block", From: from, To: to}, nil
+}
+
+func newLogOptions(offset, limit uint64) *logOptions {
+	if offset == 0 && limit == 0 {
+		return nil
+	}
+	if limit == 0 {
+		limit = math.MaxUint32
+	}
+	return &logOptions{Offset: offset, Limit: limit}
+}
+
+func orderOrDefault(o Order) Order {
+	if o == "" {
+		return OrderAsc
+	}
+	return o
+}
+
+// TransferCriteria describes a historical transfer log query.
+type TransferCriteria struct {
+	FromBlock uint32
+	ToBlock   uint32
+	Order     Order  // asc if empty
+	Offset    uint64 // offset of pagination
+	Limit     uint64 // limit of pagination, no limit if 0
+
+	// Filters are ORed, an empty filter set matches all transfers.
+	Filters []TransferFilter
+}
+
+// TransferFilter filters transfers, a nil field matches any value.
+type TransferFilter struct {
+	TxOrigin  *meter.Address `json:"txOrigin,omitempty"`
+	Sender    *meter.Address `json:"sender,omitempty"`
+	Recipient *meter.Address `json:"recipient,omitempty"`
+}
+
+type transferQuery struct {
+	Range       *logRange        `json:"range"`
+	Options     *logOptions      `json:"options,omitempty"`
+	CriteriaSet []TransferFilter `json:"criteriaSet,omitempty"`
+	Order       Order            `json:"order"`
+}
+
+// FilterTransfers queries historical transfer logs of MTR and MTRG.
+// The block range is not limited here, a query the node refuses to serve fails with its *HTTPError.
+func (c *Client) FilterTransfers(ctx context.Context, criteria TransferCriteria) ([]*Transfer, error) {
+	r, err := newLogRange(criteria.FromBlock, criteria.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	query := transferQuery{
+		Range:       r,
+		Options:     newLogOptions(criteria.Offset, criteria.Limit),
+		CriteriaSet: criteria.Filters,
+		Order:       orderOrDefault(criteria.Order),
+	}
+
+	var transfers []*Transfer
+	if err := c.httpPost(ctx, "/logs/transfer", nil, &query, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
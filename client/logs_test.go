@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFilterEventsRange(t *testing.T) {
+	const limitMsg = "the number of filtered logs exceeds the maximum allowed value of 1000"
+	var got eventQuery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = eventQuery{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		if got.Options == nil {
+			http.Error(w, limitMsg, http.StatusForbidden)
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// any range is sent to the node, which applies its own limits
+	ctx := context.Background()
+	if _, err := c.FilterEvents(ctx, EventCriteria{FromBlock: 0, ToBlock: 50000000, Limit: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Range.From != 0 || got.Range.To != 50000000 {
+		t.Errorf("range = %+v", got.Range)
+	}
+
+	_, err = c.FilterEvents(ctx, EventCriteria{FromBlock: 0, ToBlock: 50000000})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusForbidden || !strings.Contains(httpErr.Body, limitMsg) {
+		t.Errorf("expected the node's limit error, got %v", err)
+	}
+
+	if _, err := c.FilterEvents(ctx, EventCriteria{FromBlock: 2, ToBlock: 1}); err == nil {
+		t.Error("expected error for inverted range")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"meter-go/meter"
+)
+
+// Network is a well-known meter network.
+type Network int
+
+// Networks.
+const (
+	NetworkUnknown Network = iota
+	NetworkMainnet
+	NetworkTestnet // warringstakes testnet
+)
+
+type networkInfo struct {
+	name        string
+	baseURL     string
+	chainTag    byte
+	genesisID   meter.Bytes32 // zero until pinned, VerifyNetwork refuses unpinned networks
+	explorerURL string
+}
+
+// The public nodes serve the restful API over plain http on port 8669, with no TLS endpoint,
+// so the presets keep the explicit http scheme, and can't be combined with WithTLSConfig.
+// Use NewClient with an https node, e.g. behind a TLS terminating proxy, where TLS is required.
+//
+// Genesis ids have to be taken from the nodes themselves, and are not pinned yet.
+// Until they are, VerifyNetwork fails for the presets rather than trusting the chain tag alone,
+// which networks may share.
+var networks = map[Network]networkInfo{
+	NetworkMainnet: {"mainnet", "http://mainnet.meter.io:8669", 82, meter.Bytes32{}, mainnetExplorerURL},
+	NetworkTestnet: {"warringstakes", "http://warringstakes.meter.io:8669", 88, meter.Bytes32{}, testnetExplorerURL},
+}
+
+// String implements the stringer interface.
+func (n Network) String() string {
+	if info, ok := networks[n]; ok {
+		return info.name
+	}
+	return "unknown"
+}
+
+// BaseURL returns the url of the public node.
+func (n Network) BaseURL() string {
+	return networks[n].baseURL
+}
+
+// ChainTag returns the expected chain tag, which is the last byte of genesis id.
+func (n Network) ChainTag() byte {
+	return networks[n].chainTag
+}
+
+// GenesisID returns the expected genesis block id, or zero if it's not pinned.
+func (n Network) GenesisID() meter.Bytes32 {
+	return networks[n].genesisID
+}
+
+// ExplorerURL returns the url of the block explorer.
+func (n Network) ExplorerURL() string {
+	return networks[n].explorerURL
+}
+
+// NewNetworkClient create a client connected to the public node of network.
+func NewNetworkClient(n Network, opts ...ClientOption) (*Client, error) {
+	if _, ok := networks[n]; !ok {
+		return nil, errors.New("unknown network")
+	}
+	c, err := NewClient(n.BaseURL(), append([]ClientOption{WithExplorer(n.ExplorerURL())}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	c.network = n
+	return c, nil
+}
+
+// Mainnet create a client connected to the public mainnet node.
+func Mainnet(opts ...ClientOption) (*Client, error) {
+	return NewNetworkClient(NetworkMainnet, opts...)
+}
+
+// Testnet create a client connected to the public warringstakes testnet node.
+func Testnet(opts ...ClientOption) (*Client, error) {
+	return NewNetworkClient(NetworkTestnet, opts...)
+}
+
+// Network returns the network of a preset client, or NetworkUnknown.
+func (c *Client) Network() Network {
+	return c.network
+}
+
+// VerifyNetwork checks the genesis id of the connected node against the preset network.
+// It fails if the network has no pinned genesis id.
+func (c *Client) VerifyNetwork(ctx context.Context) error {
+	if c.network == NetworkUnknown {
+		return errors.New("unknown network")
+	}
+	expected := c.network.GenesisID()
+	if expected == (meter.Bytes32{}) {
+		return fmt.Errorf("genesis id of %v not pinned", c.network)
+	}
+	genesis, err := c.GetBlock(ctx, RevisionNumber(0))
+	if err != nil {
+		return err
+	}
+	if genesis == nil {
+		return errors.New("genesis block not found")
+	}
+	if genesis.ID != expected {
+		return fmt.Errorf("genesis id mismatch: %v expects %v, node has %v", c.network, expected, genesis.ID)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"meter-go/meter"
+)
+
+func TestNetworkPresets(t *testing.T) {
+	for n := range networks {
+		if n.BaseURL() == "" || n.ChainTag() == 0 {
+			t.Errorf("%v: incomplete preset", n)
+		}
+		id := n.GenesisID()
+		if id == (meter.Bytes32{}) {
+			// not pinned yet, VerifyNetwork must refuse it rather than trust the chain tag
+			c := &Client{network: n}
+			if err := c.VerifyNetwork(context.Background()); err == nil || !strings.Contains(err.Error(), "not pinned") {
+				t.Errorf("%v: VerifyNetwork accepted an unpinned network: %v", n, err)
+			}
+			continue
+		}
+		// a block id starts with its number, which is 0 for genesis, and ends in the chain tag
+		if id[0]|id[1]|id[2]|id[3] != 0 {
+			t.Errorf("%v: genesis id %v is not of block 0", n, id)
+		}
+		if id[31] != n.ChainTag() {
+			t.Errorf("%v: genesis id %v does not end in chain tag %d", n, id, n.ChainTag())
+		}
+	}
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	srv, _ := newTestNode(t)
+
+	// the test node's genesis id ends in 0x52, the same chain tag as the other network
+	var genesisID, otherID meter.Bytes32
+	genesisID[31] = 0x52
+	otherID[30], otherID[31] = 0x01, 0x52
+	const (
+		testNetwork Network = iota + 100
+		otherNetwork
+	)
+	networks[testNetwork] = networkInfo{"test", srv.URL, 0x52, genesisID, ""}
+	networks[otherNetwork] = networkInfo{"other", srv.URL, 0x52, otherID, ""}
+	t.Cleanup(func() {
+		delete(networks, testNetwork)
+		delete(networks, otherNetwork)
+	})
+
+	ctx := context.Background()
+	c, err := NewNetworkClient(testNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyNetwork(ctx); err != nil {
+		t.Errorf("VerifyNetwork: %v", err)
+	}
+
+	c, err = NewNetworkClient(otherNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyNetwork(ctx); err == nil || !strings.Contains(err.Error(), "genesis id mismatch") {
+		t.Errorf("expected genesis id mismatch despite equal chain tags, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"meter-go/abi"
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+var (
+	// paramsAddress is the address of the builtin params contract, bytes of "Params".
+	paramsAddress = meter.MustParseAddress("0x0000000000000000000000000000506172616d73")
+	// keyBaseGasPrice is the params key of base gas price, left padded bytes of "base-gas-price".
+	keyBaseGasPrice = meter.MustParseBytes32("0x000000000000000000000000000000000000626173652d6761732d7072696365")
+)
+
+// BaseGasPrice returns the base gas price at revision, which is read from the builtin params contract.
+func (c *Client) BaseGasPrice(ctx context.Context, rev Revision) (*big.Int, error) {
+	data, err := abi.EncodeCall("get(bytes32)", keyBaseGasPrice)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.Inspect(ctx, []*tx.Clause{tx.NewClause(&paramsAddress).WithData(data)}, nil, rev)
+	if err != nil {
+		return nil, err
+	}
+	r := results[0]
+	if r.Reverted {
+		return nil, newRevertError(0, r)
+	}
+	if len(r.Data) != 32 {
+		return nil, errors.New("invalid base gas price data")
+	}
+	return new(big.Int).SetBytes(r.Data), nil
+}
+
+// EffectiveGasPrice returns the MTR price per gas t pays at the best block, i.e. t.GasPrice of
+// the current base gas price. The max fee of t is the price times t.Gas().
+func (c *Client) EffectiveGasPrice(ctx context.Context, t *tx.Transaction) (*big.Int, error) {
+	base, err := c.BaseGasPrice(ctx, RevisionBest())
+	if err != nil {
+		return nil, err
+	}
+	return t.GasPrice(base), nil
+}
+
+// intervalSampleBlocks is the count of recent blocks the block interval is averaged over.
+const intervalSampleBlocks = 100
+
+// Params returns the chain params of the connected node.
+// They are fetched once and cached afterwards: chain tag and genesis id from the genesis block,
+// and the block interval averaged over recent blocks.
+func (c *Client) Params(ctx context.Context) (tx.ChainParams, error) {
+	c.paramsLock.Lock()
+	cached := c.params
+	c.paramsLock.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	params, err := c.fetchParams(ctx)
+	if err != nil {
+		return tx.ChainParams{}, err
+	}
+
+	c.paramsLock.Lock()
+	defer c.paramsLock.Unlock()
+	if c.params == nil {
+		c.params = &params
+	}
+	return *c.params, nil
+}
+
+func (c *Client) fetchParams(ctx context.Context) (tx.ChainParams, error) {
+	genesis, err := c.GetBlock(ctx, RevisionNumber(0))
+	if err != nil {
+		return tx.ChainParams{}, err
+	}
+	if genesis == nil {
+		return tx.ChainParams{}, errors.New("genesis block not found")
+	}
+	interval, err := c.blockInterval(ctx)
+	if err != nil {
+		return tx.ChainParams{}, err
+	}
+	return tx.ChainParams{
+		BlockInterval: interval,
+		ChainTag:      genesis.ID[31],
+		GenesisID:     genesis.ID,
+	}, nil
+}
+
+// blockInterval returns the average interval of recent blocks, or tx.DefaultBlockInterval
+// for a chain without blocks to measure.
+func (c *Client) blockInterval(ctx context.Context) (time.Duration, error) {
+	best, err := c.GetBestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if best == nil {
+		return 0, errors.New("best block not found")
+	}
+	span := uint32(intervalSampleBlocks)
+	if best.Number < span {
+		span = best.Number
+	}
+	if span == 0 {
+		return tx.DefaultBlockInterval, nil
+	}
+	past, err := c.GetBlock(ctx, RevisionNumber(best.Number-span))
+	if err != nil {
+		return 0, err
+	}
+	if past == nil {
+		return 0, fmt.Errorf("block %d not found", best.Number-span)
+	}
+	if best.Timestamp <= past.Timestamp {
+		return tx.DefaultBlockInterval, nil
+	}
+	return time.Duration(best.Timestamp-past.Timestamp) * time.Second / time.Duration(span), nil
+}
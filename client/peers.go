@@ -0,0 +1,39 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/binary"
+
+	"meter-go/meter"
+)
+
+// PeerInfo is a peer connected to the node.
+type PeerInfo struct {
+	Name        string        `json:"name"`
+	BestBlockID meter.Bytes32 `json:"bestBlockID"`
+	TotalScore  uint64        `json:"totalScore"`
+	PeerID      string        `json:"peerID"`
+	NetAddr     string        `json:"netAddr"`
+	Inbound     bool          `json:"inbound"`  // whether the peer dialed the node
+	Duration    uint64        `json:"duration"` // connected seconds
+}
+
+// BestBlockNumber returns the number of the peer's best block, which is the first 4 bytes of its id.
+func (p *PeerInfo) BestBlockNumber() uint32 {
+	return binary.BigEndian.Uint32(p.BestBlockID[:4])
+}
+
+// Peers returns the peers connected to the node, e.g. to diagnose poor tx propagation.
+// The peer count is the length of the result.
+func (c *Client) Peers(ctx context.Context) ([]PeerInfo, error) {
+	var peers []PeerInfo
+	if err := c.httpGet(ctx, "/node/network/peers", nil, &peers); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// node is an endpoint of the client, with its health records.
+type node struct {
+	url string
+
+	lock        sync.Mutex
+	failures    int // consecutive failures
+	lastError   error
+	lastSuccess time.Time
+}
+
+// record updates the node health with the result of a request made with ctx.
+// Requests aborted by the caller tell nothing about the node, and are not recorded.
+func (n *node) record(ctx context.Context, err error) {
+	if isCallerAbort(ctx, err) {
+		return
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if isNodeFailure(ctx, err) {
+		n.failures++
+		n.lastError = err
+	} else {
+		n.failures = 0
+		n.lastSuccess = time.Now()
+	}
+}
+
+func (n *node) healthy() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.failures == 0
+}
+
+// NodeHealth is the health of a node.
+type NodeHealth struct {
+	URL                 string
+	ConsecutiveFailures int
+	LastError           error
+	LastSuccess         time.Time
+}
+
+// isNodeFailure returns whether err is caused by the node, e.g. connection errors or 5xx status,
+// rather than the request itself, or the caller cancelling ctx.
+func isNodeFailure(ctx context.Context, err error) bool {
+	if err == nil || isCallerAbort(ctx, err) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	return !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr)
+}
+
+// isCallerAbort returns whether err is caused by ctx being cancelled or past its deadline.
+func isCallerAbort(ctx context.Context, err error) bool {
+	return ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded))
+}
+
+// WithBroadcastQuorum set the count of nodes a tx must be accepted by in SendTransaction.
+// It's capped to the count of nodes, and defaults to 1.
+func WithBroadcastQuorum(n int) ClientOption {
+	return func(c *Client) {
+		c.quorum = n
+	}
+}
+
+// NewClientPool create a client over several nodes.
+// Requests go to nodes in round-robin, preferring healthy ones, and fall through to
+// the next node on connection errors or 5xx status, while txs are broadcast to all nodes.
+func NewClientPool(urls []string, opts ...ClientOption) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no node url")
+	}
+	nodes := make([]*node, 0, len(urls))
+	for _, u := range urls {
+		normalized, err := normalizeURL(u)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &node{url: normalized})
+	}
+	return NewClient(urls[0], append([]ClientOption{func(c *Client) { c.nodes = nodes }}, opts...)...)
+}
+
+// NodeHealth returns health of every node.
+func (c *Client) NodeHealth() []NodeHealth {
+	healths := make([]NodeHealth, 0, len(c.nodes))
+	for _, n := range c.nodes {
+		n.lock.Lock()
+		healths = append(healths, NodeHealth{
+			URL:                 n.url,
+			ConsecutiveFailures: n.failures,
+			LastError:           n.lastError,
+			LastSuccess:         n.lastSuccess,
+		})
+		n.lock.Unlock()
+	}
+	return healths
+}
+
+// pickNodes returns nodes in the order to try, healthy ones first, starting from the round-robin index.
+func (c *Client) pickNodes() []*node {
+	if len(c.nodes) == 1 {
+		return c.nodes
+	}
+	start := int(atomic.AddUint32(&c.next, 1)) % len(c.nodes)
+	var healthy, unhealthy []*node
+	for i := range c.nodes {
+		n := c.nodes[(start+i)%len(c.nodes)]
+		if n.healthy() {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// broadcast posts body to all nodes concurrently, and succeeds if at least quorum nodes accept it.
+// The result is decoded from the first accepting node.
+func (c *Client) broadcast(ctx context.Context, path string, body interface{}, result interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	if len(c.nodes) == 1 {
+		return c.httpDo(ctx, http.MethodPost, path, nil, data, result)
+	}
+
+	quorum := c.quorum
+	if quorum < 1 {
+		quorum = 1
+	} else if quorum > len(c.nodes) {
+		quorum = len(c.nodes)
+	}
+
+	type response struct {
+		data json.RawMessage
+		err  error
+	}
+	responses := make(chan response, len(c.nodes))
+	for _, n := range c.nodes {
+		go func(n *node) {
+			var raw json.RawMessage
+			err := c.httpDoNode(ctx, n, http.MethodPost, path, nil, data, &raw)
+			if isTooManyRequests(err) {
+				err = c.backoff(ctx, func() error {
+					return c.httpDoNode(ctx, n, http.MethodPost, path, nil, data, &raw)
+				})
+			}
+			responses <- response{raw, err}
+		}(n)
+	}
+
+	var (
+		accepted int
+		first    json.RawMessage
+		errs     []string
+		firstErr error
+	)
+	for range c.nodes {
+		res := <-responses
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			errs = append(errs, res.err.Error())
+			continue
+		}
+		if accepted++; accepted == 1 {
+			first = res.data
+		}
+		if accepted >= quorum {
+			break
+		}
+	}
+	if accepted < quorum {
+		if accepted == 0 && len(errs) == len(c.nodes) {
+			// all nodes failed, return the first error which may be a typed one
+			return firstErr
+		}
+		return fmt.Errorf("broadcast accepted by %d nodes, quorum %d: %s", accepted, quorum, strings.Join(errs, "; "))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(first, result)
+}
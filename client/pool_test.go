@@ -0,0 +1,55 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNodeHealthCallerAbort(t *testing.T) {
+	// a healthy but slow node, which answers only after the caller gave up
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c, err := NewClientPool([]string{slow.URL, down.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	health := func(url string) NodeHealth {
+		for _, h := range c.NodeHealth() {
+			if h.URL == url {
+				return h
+			}
+		}
+		t.Fatalf("no node %s", url)
+		return NodeHealth{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	for i := 0; i < 2; i++ {
+		// every node is tried, down fails on its own while slow is cancelled
+		c.GetBestBlock(ctx)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.GetBestBlock(ctx)
+
+	if h := health(slow.URL); h.ConsecutiveFailures != 0 || h.LastError != nil {
+		t.Errorf("caller abort recorded as node failure: %+v", h)
+	}
+	if h := health(down.URL); h.ConsecutiveFailures == 0 {
+		t.Errorf("connection error not recorded: %+v", h)
+	}
+}
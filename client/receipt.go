@@ -0,0 +1,153 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// ReceiptMeta describes where a receipt is included.
+type ReceiptMeta struct {
+	BlockID        meter.Bytes32 `json:"blockID"`
+	BlockNumber    uint32        `json:"blockNumber"`
+	BlockTimestamp uint64        `json:"blockTimestamp"`
+	TxID           meter.Bytes32 `json:"txID"`
+	TxOrigin       meter.Address `json:"txOrigin"`
+}
+
+// Output is the output of a clause execution.
+type Output struct {
+	ContractAddress *meter.Address `json:"contractAddress"`
+	Events          []*Event       `json:"events"`
+	Transfers       []*Transfer    `json:"transfers"`
+}
+
+// Receipt is the receipt of an executed tx.
+type Receipt struct {
+	GasUsed  uint64
+	GasPayer meter.Address
+	Paid     *big.Int
+	Reward   *big.Int
+	Reverted bool
+	Meta     ReceiptMeta
+	Outputs  []*Output
+}
+
+type receiptJSON struct {
+	GasUsed  uint64                `json:"gasUsed"`
+	GasPayer meter.Address         `json:"gasPayer"`
+	Paid     *math.HexOrDecimal256 `json:"paid"`
+	Reward   *math.HexOrDecimal256 `json:"reward"`
+	Reverted bool                  `json:"reverted"`
+	Meta     ReceiptMeta           `json:"meta"`
+	Outputs  []*Output             `json:"outputs"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Receipt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&receiptJSON{
+		r.GasUsed,
+		r.GasPayer,
+		(*math.HexOrDecimal256)(r.Paid),
+		(*math.HexOrDecimal256)(r.Reward),
+		r.Reverted,
+		r.Meta,
+		r.Outputs,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	var rj receiptJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+	*r = Receipt{
+		GasUsed:  rj.GasUsed,
+		GasPayer: rj.GasPayer,
+		Paid:     bigOrZero(rj.Paid),
+		Reward:   bigOrZero(rj.Reward),
+		Reverted: rj.Reverted,
+		Meta:     rj.Meta,
+		Outputs:  rj.Outputs,
+	}
+	return nil
+}
+
+// GasByClause returns the intrinsic gas attributed to each clause of the tx.
+// The node doesn't report gas per clause output, so only the intrinsic part (clause gas
+// plus data gas) is attributed, while the tx base gas and the VM execution gas are not.
+// Simulate single clauses with Inspect to measure their VM gas.
+func (r *Receipt) GasByClause(clauses []*tx.Clause) ([]uint64, error) {
+	if len(clauses) != len(r.Outputs) && !r.Reverted {
+		return nil, fmt.Errorf("clause count %d mismatches output count %d", len(clauses), len(r.Outputs))
+	}
+	gases := make([]uint64, 0, len(clauses))
+	for _, c := range clauses {
+		gas, err := c.IntrinsicGas()
+		if err != nil {
+			return nil, err
+		}
+		gases = append(gases, gas)
+	}
+	return gases, nil
+}
+
+// NetBalanceChange returns the net amounts of tokens addr received minus sent by transfers of
+// the tx, by token type. Tokens without transfers involving addr are absent.
+// Gas payment is not a transfer, so it's not included, see GasPaidBy for the complete accounting.
+func (r *Receipt) NetBalanceChange(addr meter.Address) map[tx.TokenType]*big.Int {
+	changes := make(map[tx.TokenType]*big.Int)
+	for _, o := range r.Outputs {
+		if o == nil {
+			continue
+		}
+		for _, t := range o.Transfers {
+			if t == nil || t.Amount == nil || t.Sender == t.Recipient {
+				continue
+			}
+			if t.Sender != addr && t.Recipient != addr {
+				continue
+			}
+			token := tx.TokenType(t.Token)
+			change, ok := changes[token]
+			if !ok {
+				change = new(big.Int)
+				changes[token] = change
+			}
+			if t.Recipient == addr {
+				change.Add(change, t.Amount)
+			} else {
+				change.Sub(change, t.Amount)
+			}
+		}
+	}
+	return changes
+}
+
+// GasPaidBy returns the MTR addr paid for gas of the tx, which is zero unless addr is the gas payer.
+// Subtract it from the MTR entry of NetBalanceChange for the total change of balance.
+func (r *Receipt) GasPaidBy(addr meter.Address) *big.Int {
+	if addr != r.GasPayer || r.Paid == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(r.Paid)
+}
+
+// bigOrZero returns a copy of v, or zero if v is nil.
+func bigOrZero(v *math.HexOrDecimal256) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set((*big.Int)(v))
+}
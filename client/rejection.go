@@ -0,0 +1,90 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// RejectReason is the reason why the node rejected a tx.
+type RejectReason int
+
+// Reasons of tx rejection.
+const (
+	RejectUnknown RejectReason = iota
+	RejectBadChainTag
+	RejectExpired
+	RejectIntrinsicGasExceeded
+	RejectInsufficientEnergy
+	RejectKnownTx
+)
+
+// rejectPatterns maps message fragments of the node's txpool errors to reasons.
+var rejectPatterns = []struct {
+	fragment string
+	reason   RejectReason
+}{
+	{"chain tag mismatch", RejectBadChainTag},
+	{"expired", RejectExpired},
+	{"intrinsic gas exceeds provided gas", RejectIntrinsicGasExceeded},
+	{"insufficient energy", RejectInsufficientEnergy},
+	{"known tx", RejectKnownTx},
+}
+
+// String returns the name of the reason.
+func (r RejectReason) String() string {
+	switch r {
+	case RejectBadChainTag:
+		return "bad chain tag"
+	case RejectExpired:
+		return "expired"
+	case RejectIntrinsicGasExceeded:
+		return "intrinsic gas exceeds provided gas"
+	case RejectInsufficientEnergy:
+		return "insufficient energy"
+	case RejectKnownTx:
+		return "known tx"
+	}
+	return "unknown"
+}
+
+// RejectionError is returned by SendTransaction when the node rejects the tx.
+type RejectionError struct {
+	Reason  RejectReason
+	Message string // the raw message from the node
+}
+
+func (e *RejectionError) Error() string {
+	return "tx rejected: " + e.Message
+}
+
+// parseRejectReason returns the reason of a rejection message.
+func parseRejectReason(msg string) RejectReason {
+	msg = strings.ToLower(msg)
+	for _, p := range rejectPatterns {
+		if strings.Contains(msg, p.fragment) {
+			return p.reason
+		}
+	}
+	return RejectUnknown
+}
+
+// asRejectionError converts a 4xx HTTPError into RejectionError, and returns other errors unchanged.
+// A 429 left after backoff is returned as is, as the node throttled the request rather than
+// rejecting the tx.
+func asRejectionError(err error) error {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) ||
+		httpErr.StatusCode == http.StatusTooManyRequests ||
+		httpErr.StatusCode < http.StatusBadRequest ||
+		httpErr.StatusCode >= http.StatusInternalServerError {
+		return err
+	}
+	msg := strings.TrimSpace(httpErr.Body)
+	return &RejectionError{Reason: parseRejectReason(msg), Message: msg}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"strconv"
+
+	"meter-go/meter"
+)
+
+// Revision refers to a block to query states at.
+// The zero value refers to the best block.
+type Revision struct {
+	s string
+}
+
+// RevisionBest refers to the best block.
+func RevisionBest() Revision {
+	return Revision{"best"}
+}
+
+// RevisionNumber refers to the block of number on the trunk.
+func RevisionNumber(n uint32) Revision {
+	return Revision{strconv.FormatUint(uint64(n), 10)}
+}
+
+// RevisionID refers to the block of id.
+func RevisionID(id meter.Bytes32) Revision {
+	return Revision{id.String()}
+}
+
+// String returns the revision in the form the node expects.
+func (r Revision) String() string {
+	if r.s == "" {
+		return "best"
+	}
+	return r.s
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"meter-go/meter"
+)
+
+// EpochInfo describes the PoS epoch of the best block.
+// An epoch starts with a K-block, which elects the committee validating the following blocks.
+type EpochInfo struct {
+	Epoch        uint64
+	KBlockNumber uint32 // number of the K-block starting the epoch
+	BestNumber   uint32
+	BestID       meter.Bytes32
+}
+
+type epochBlockJSON struct {
+	Number           uint32        `json:"number"`
+	ID               meter.Bytes32 `json:"id"`
+	Epoch            uint64        `json:"epoch"`
+	LastKBlockHeight uint32        `json:"lastKBlockHeight"`
+}
+
+// CurrentEpoch returns the epoch of the best block.
+func (c *Client) CurrentEpoch(ctx context.Context) (*EpochInfo, error) {
+	var b *epochBlockJSON
+	if err := c.httpGet(ctx, "/blocks/best", nil, &b); err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("best block not found")
+	}
+	return &EpochInfo{
+		Epoch:        b.Epoch,
+		KBlockNumber: b.LastKBlockHeight,
+		BestNumber:   b.Number,
+		BestID:       b.ID,
+	}, nil
+}
+
+// Validator is a member of the consensus committee.
+// PubKey is in the node's form, the base64 ECDSA key and the base64 BLS key joined by ":::".
+type Validator struct {
+	Name        string
+	Address     meter.Address
+	PubKey      string
+	VotingPower *big.Int
+	NetAddr     string
+	InCommittee bool
+}
+
+type validatorJSON struct {
+	Name        string        `json:"name"`
+	Address     meter.Address `json:"address"`
+	PubKey      string        `json:"pubKey"`
+	VotingPower json.Number   `json:"votingPower"`
+	NetAddr     string        `json:"netAddr"`
+	InCommittee bool          `json:"inCommittee"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The voting power is accepted as a json number or a decimal string.
+func (v *Validator) UnmarshalJSON(data []byte) error {
+	var vj validatorJSON
+	if err := json.Unmarshal(data, &vj); err != nil {
+		return err
+	}
+	power := new(big.Int)
+	if vj.VotingPower != "" {
+		if _, ok := power.SetString(vj.VotingPower.String(), 10); !ok {
+			return fmt.Errorf("invalid voting power %q", vj.VotingPower)
+		}
+	}
+	*v = Validator{
+		Name:        vj.Name,
+		Address:     vj.Address,
+		PubKey:      vj.PubKey,
+		VotingPower: power,
+		NetAddr:     vj.NetAddr,
+		InCommittee: vj.InCommittee,
+	}
+	return nil
+}
+
+// Committee returns the consensus committee at revision.
+// The node only serves the committee in effect, so rev must be the best revision,
+// past committees can't be queried.
+func (c *Client) Committee(ctx context.Context, rev Revision) ([]Validator, error) {
+	if rev.String() != RevisionBest().String() {
+		return nil, fmt.Errorf("committee at revision %v not supported, only best", rev)
+	}
+	var validators []Validator
+	if err := c.httpGet(ctx, "/node/consensus/committee", nil, &validators); err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// ErrTxExpired is the result of a submitted tx that expired without being mined.
+var ErrTxExpired = errors.New("tx expired")
+
+// SubmitHandle tracks a submitted tx until it's mined or expired.
+type SubmitHandle struct {
+	id      meter.Bytes32
+	done    chan struct{}
+	receipt *Receipt
+	err     error
+}
+
+// ID returns the tx id.
+func (h *SubmitHandle) ID() meter.Bytes32 {
+	return h.id
+}
+
+// Done returns a channel closed when the result is available.
+func (h *SubmitHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result waits until done, and returns the receipt of the mined tx, which may be reverted,
+// or ErrTxExpired, ctx.Err() of Submit, or a request error.
+func (h *SubmitHandle) Result() (*Receipt, error) {
+	<-h.done
+	return h.receipt, h.err
+}
+
+// Submit broadcasts a signed tx, and waits in background until it's mined or expired,
+// or ctx is done. It returns error if the broadcast fails, or ErrDependencyReverted without
+// broadcasting if the tx depends on a reverted tx.
+func (c *Client) Submit(ctx context.Context, t *tx.Transaction) (*SubmitHandle, error) {
+	return Submit(ctx, c, t)
+}
+
+// Submit is Client.Submit against any backend.
+func Submit(ctx context.Context, b Backend, t *tx.Transaction) (*SubmitHandle, error) {
+	if dep := t.DependsOn(); dep != nil {
+		status, err := DependencyStatus(ctx, b, *dep)
+		if err != nil {
+			return nil, err
+		}
+		if status == DepReverted {
+			return nil, ErrDependencyReverted
+		}
+	}
+	id, err := b.SendTransaction(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	h := &SubmitHandle{id: id, done: make(chan struct{})}
+	go func() {
+		defer close(h.done)
+		h.receipt, h.err = waitForInclusion(ctx, b, t, id)
+	}()
+	return h, nil
+}
+
+// waitForInclusion waits for the receipt of tx, until the tx expires.
+// Receipts are polled by WaitForReceipt, with a deadline estimated from the remaining blocks
+// to the expiration, which is then confirmed by the best block.
+func waitForInclusion(ctx context.Context, b Backend, t *tx.Transaction, id meter.Bytes32) (*Receipt, error) {
+	var remaining uint32 = 1
+	for {
+		wctx, cancel := context.WithTimeout(ctx, time.Duration(remaining+1)*tx.DefaultBlockInterval)
+		r, err := WaitForReceipt(wctx, b, id, 0)
+		cancel()
+		if err == nil {
+			return r, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+
+		best, err := b.GetBestBlock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil {
+			return nil, errors.New("best block not found")
+		}
+		if t.IsExpiredAt(best) {
+			// the tx might be mined in the last valid block
+			r, err := b.GetTransactionReceipt(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if r != nil {
+				return r, nil
+			}
+			return nil, ErrTxExpired
+		}
+		remaining = t.ExpiresAtBlock() - best.Number
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// fatalError marks a subscription error that reconnecting won't fix.
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+// SubscribeBlocks streams new blocks from the node's websocket endpoint, until ctx is canceled.
+// Transient disconnections are recovered by reconnecting from the last received block,
+// while fatal errors are sent to the error channel, after which both channels are closed.
+func (c *Client) SubscribeBlocks(ctx context.Context) (<-chan *Block, <-chan error, error) {
+	blocks := make(chan *Block)
+	errs, err := c.subscribe(ctx, "/subscriptions/block", url.Values{}, func(data []byte) (string, error) {
+		var b Block
+		if err := json.Unmarshal(data, &b); err != nil {
+			return "", err
+		}
+		select {
+		case blocks <- &b:
+		case <-ctx.Done():
+		}
+		return b.ID.String(), nil
+	}, func() { close(blocks) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return blocks, errs, nil
+}
+
+// wsURL converts the http url of a node into websocket url.
+func wsURL(baseURL, path string, query url.Values) string {
+	u := baseURL + path
+	if strings.HasPrefix(u, "https://") {
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	} else if strings.HasPrefix(u, "http://") {
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// newWSDialer creates the websocket dialer sharing the proxy and TLS settings of the default http client.
+func (c *Client) newWSDialer() *websocket.Dialer {
+	return &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+		TLSClientConfig:  c.tlsConfig,
+	}
+}
+
+// dialSubscription dials the subscription endpoint of nodes in turn, until one completes the handshake.
+// A handshake refused with a non-5xx status fails at once, as other nodes would refuse it too.
+func (c *Client) dialSubscription(ctx context.Context, path string, query url.Values) (*websocket.Conn, error) {
+	var err error
+	for _, n := range c.pickNodes() {
+		var conn *websocket.Conn
+		if conn, err = c.dialNode(ctx, n, path, query); err == nil {
+			return conn, nil
+		}
+		if _, ok := err.(*fatalError); ok || ctx.Err() != nil {
+			return nil, err
+		}
+		c.logWarn("node failed, retrying next", "url", n.url, "err", err)
+	}
+	return nil, err
+}
+
+// dialNode dials the subscription endpoint of a single node, and records the node health.
+func (c *Client) dialNode(ctx context.Context, n *node, path string, query url.Values) (conn *websocket.Conn, err error) {
+	u := wsURL(n.url, path, query)
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var status int
+	done := c.traceRequest(http.MethodGet, u)
+	defer func() { done(status, err) }()
+
+	conn, res, err := c.wsDialer.DialContext(ctx, u, nil)
+	if res != nil {
+		status = res.StatusCode
+	}
+	if err != nil {
+		if res != nil && res.StatusCode < http.StatusInternalServerError {
+			// the node rejected the handshake, e.g. invalid filter or position
+			n.record(ctx, nil)
+			return nil, &fatalError{fmt.Errorf("subscribe %v: %v (http status %d)", path, err, res.StatusCode)}
+		}
+		if res != nil {
+			err = &HTTPError{StatusCode: res.StatusCode, Body: err.Error()}
+		}
+		n.record(ctx, err)
+		return nil, err
+	}
+	n.record(ctx, nil)
+	return conn, nil
+}
+
+// subscribe dials the subscription endpoint and runs the read loop in background.
+// handle decodes each message and returns the position to resume from on reconnection,
+// or empty string to keep the current one.
+// onClose is called once the subscription ends.
+func (c *Client) subscribe(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	handle func(data []byte) (string, error),
+	onClose func(),
+) (<-chan error, error) {
+	conn, err := c.dialSubscription(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer onClose()
+
+		delay := minReconnectDelay
+		for {
+			err := readSubscription(ctx, conn, query, handle)
+			conn.Close()
+			if ctx.Err() != nil {
+				return
+			}
+			if _, ok := err.(*fatalError); ok {
+				errs <- err
+				return
+			}
+
+			// reconnect with exponential backoff
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				conn, err = c.dialSubscription(ctx, path, query)
+				if err == nil {
+					delay = minReconnectDelay
+					break
+				}
+				if _, ok := err.(*fatalError); ok {
+					errs <- err
+					return
+				}
+				if delay *= 2; delay > maxReconnectDelay {
+					delay = maxReconnectDelay
+				}
+			}
+		}
+	}()
+	return errs, nil
+}
+
+// readSubscription reads messages until the connection breaks or ctx is done.
+// The "pos" query is updated with the latest position.
+func readSubscription(ctx context.Context, conn *websocket.Conn, query url.Values, handle func(data []byte) (string, error)) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		pos, err := handle(data)
+		if err != nil {
+			return &fatalError{fmt.Errorf("decode subscription message: %v", err)}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if pos != "" {
+			query.Set("pos", pos)
+		}
+	}
+}
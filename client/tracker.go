@@ -0,0 +1,124 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// TrackerCallbacks are called by Tracker when a tracked tx reaches a terminal state.
+// Nil callbacks are skipped.
+type TrackerCallbacks struct {
+	Mined    func(t *tx.Transaction, r *Receipt) // mined and not reverted
+	Reverted func(t *tx.Transaction, r *Receipt) // mined but reverted
+	Expired  func(t *tx.Transaction)             // expired without being mined
+}
+
+// Tracker tracks broadcast txs until they are mined or expired.
+// It's safe for concurrent use.
+type Tracker struct {
+	b         Backend
+	callbacks TrackerCallbacks
+
+	lock    sync.Mutex
+	pending map[meter.Bytes32]*tx.Transaction
+}
+
+// NewTracker create a tracker polling the backend, which is usually a *Client.
+func NewTracker(b Backend, callbacks TrackerCallbacks) *Tracker {
+	return &Tracker{
+		b:         b,
+		callbacks: callbacks,
+		pending:   make(map[meter.Bytes32]*tx.Transaction),
+	}
+}
+
+// Add starts tracking a signed tx.
+// It fails for unsigned txs, which have no id to look receipts up by.
+func (tr *Tracker) Add(t *tx.Transaction) error {
+	if len(t.Signature()) == 0 {
+		return errors.New("tx not signed")
+	}
+	if _, err := t.Signer(); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	id := t.ID()
+	if id == (meter.Bytes32{}) {
+		return errors.New("tx has no id")
+	}
+
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	tr.pending[id] = t
+	return nil
+}
+
+// Pending returns ids of txs still being tracked.
+func (tr *Tracker) Pending() []meter.Bytes32 {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	ids := make([]meter.Bytes32, 0, len(tr.pending))
+	for id := range tr.pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Poll checks every tracked tx for a receipt, or expiration against the best block.
+// Txs in a terminal state are reported through callbacks and dropped.
+func (tr *Tracker) Poll(ctx context.Context) error {
+	best, err := tr.b.GetBestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if best == nil {
+		return errors.New("best block not found")
+	}
+
+	tr.lock.Lock()
+	txs := make([]*tx.Transaction, 0, len(tr.pending))
+	for _, t := range tr.pending {
+		txs = append(txs, t)
+	}
+	tr.lock.Unlock()
+
+	for _, t := range txs {
+		r, err := tr.b.GetTransactionReceipt(ctx, t.ID())
+		if err != nil {
+			return err
+		}
+		switch {
+		case r != nil:
+			tr.remove(t)
+			if r.Reverted {
+				if tr.callbacks.Reverted != nil {
+					tr.callbacks.Reverted(t, r)
+				}
+			} else if tr.callbacks.Mined != nil {
+				tr.callbacks.Mined(t, r)
+			}
+		case t.IsExpiredAt(best):
+			tr.remove(t)
+			if tr.callbacks.Expired != nil {
+				tr.callbacks.Expired(t)
+			}
+		}
+	}
+	return nil
+}
+
+func (tr *Tracker) remove(t *tx.Transaction) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	delete(tr.pending, t.ID())
+}
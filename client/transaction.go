@@ -0,0 +1,261 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DefaultPollInterval is the default interval of polling receipts.
+const DefaultPollInterval = time.Second
+
+type rawTx struct {
+	Raw string `json:"raw"`
+}
+
+type txID struct {
+	ID meter.Bytes32 `json:"id"`
+}
+
+// GetTxOptions controls GetTransaction.
+type GetTxOptions struct {
+	Pending bool // include txs in the pool not mined yet
+	Raw     bool // request the RLP encoded tx, which is decoded into Transaction
+}
+
+// TxMeta describes where a tx is included.
+type TxMeta struct {
+	BlockID        meter.Bytes32 `json:"blockID"`
+	BlockNumber    uint32        `json:"blockNumber"`
+	BlockTimestamp uint64        `json:"blockTimestamp"`
+}
+
+// ClientTransaction is a tx queried from the node.
+type ClientTransaction struct {
+	ID           meter.Bytes32
+	ChainTag     byte
+	BlockRef     tx.BlockRef
+	Expiration   uint32
+	Clauses      []*tx.Clause
+	GasPriceCoef uint8
+	Gas          uint64
+	Origin       meter.Address
+	Delegator    *meter.Address
+	Nonce        uint64
+	DependsOn    *meter.Bytes32
+	Size         uint32
+
+	// Meta is nil for a pending tx.
+	Meta *TxMeta
+	// Transaction is the decoded tx, only set with raw option.
+	Transaction *tx.Transaction
+}
+
+type txJSON struct {
+	ID           meter.Bytes32           `json:"id"`
+	ChainTag     byte                    `json:"chainTag"`
+	BlockRef     tx.BlockRef             `json:"blockRef"`
+	Expiration   uint32                  `json:"expiration"`
+	Clauses      []*tx.Clause            `json:"clauses"`
+	GasPriceCoef uint8                   `json:"gasPriceCoef"`
+	Gas          uint64                  `json:"gas"`
+	Origin       meter.Address           `json:"origin"`
+	Delegator    *meter.Address          `json:"delegator"`
+	Nonce        *ethmath.HexOrDecimal64 `json:"nonce"`
+	DependsOn    *meter.Bytes32          `json:"dependsOn"`
+	Size         uint32                  `json:"size"`
+	Meta         *TxMeta                 `json:"meta"`
+}
+
+type rawTxJSON struct {
+	Raw  string  `json:"raw"`
+	Meta *TxMeta `json:"meta"`
+}
+
+// GetTransaction returns the tx of id.
+// It returns nil if the tx is not found.
+func (c *Client) GetTransaction(ctx context.Context, id meter.Bytes32, opts GetTxOptions) (*ClientTransaction, error) {
+	query := url.Values{}
+	if opts.Pending {
+		query.Set("pending", "true")
+	}
+	path := "/transactions/" + id.String()
+
+	if opts.Raw {
+		query.Set("raw", "true")
+		var res *rawTxJSON
+		if err := c.httpGet(ctx, path, query, &res); err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, nil
+		}
+		return newRawClientTransaction(res)
+	}
+
+	var res *txJSON
+	if err := c.httpGet(ctx, path, query, &res); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.toClientTransaction(), nil
+}
+
+func (res *txJSON) toClientTransaction() *ClientTransaction {
+	ct := &ClientTransaction{
+		ID:           res.ID,
+		ChainTag:     res.ChainTag,
+		BlockRef:     res.BlockRef,
+		Expiration:   res.Expiration,
+		Clauses:      res.Clauses,
+		GasPriceCoef: res.GasPriceCoef,
+		Gas:          res.Gas,
+		Origin:       res.Origin,
+		Delegator:    res.Delegator,
+		DependsOn:    res.DependsOn,
+		Size:         res.Size,
+		Meta:         res.Meta,
+	}
+	if res.Nonce != nil {
+		ct.Nonce = uint64(*res.Nonce)
+	}
+	return ct
+}
+
+// PendingTransactions returns the txs among ids which are still in the node's pool, i.e. neither
+// mined nor dropped, in the order of ids.
+// The meter node can't list its pool by origin, it only finds a tx of known id with the pending
+// option, so the caller has to keep the ids of its own in-flight txs, e.g. via Tracker.
+func (c *Client) PendingTransactions(ctx context.Context, ids []meter.Bytes32) ([]*ClientTransaction, error) {
+	var pending []*ClientTransaction
+	for _, id := range ids {
+		ct, err := c.GetTransaction(ctx, id, GetTxOptions{Pending: true})
+		if err != nil {
+			return nil, err
+		}
+		if ct != nil && ct.Meta == nil {
+			pending = append(pending, ct)
+		}
+	}
+	return pending, nil
+}
+
+func newRawClientTransaction(res *rawTxJSON) (*ClientTransaction, error) {
+	t, err := tx.Decode(res.Raw)
+	if err != nil {
+		return nil, err
+	}
+	origin, err := t.Origin()
+	if err != nil {
+		return nil, err
+	}
+	delegator, err := t.DelegatorSigner()
+	if err != nil {
+		return nil, err
+	}
+	return &ClientTransaction{
+		ID:           t.ID(),
+		ChainTag:     t.ChainTag(),
+		BlockRef:     t.BlockRef(),
+		Expiration:   t.Expiration(),
+		Clauses:      t.Clauses(),
+		GasPriceCoef: t.GasPriceCoef(),
+		Gas:          t.Gas(),
+		Origin:       origin,
+		Delegator:    delegator,
+		Nonce:        t.Nonce(),
+		DependsOn:    t.DependsOn(),
+		Size:         uint32(t.Size()),
+		Meta:         res.Meta,
+		Transaction:  t,
+	}, nil
+}
+
+// SendTransaction broadcasts a signed tx, and returns its id.
+// For a client pool, the tx is sent to all nodes, see WithBroadcastQuorum.
+// A tx refused by the node results in *RejectionError.
+func (c *Client) SendTransaction(ctx context.Context, t *tx.Transaction) (meter.Bytes32, error) {
+	data, err := rlp.EncodeToBytes(t)
+	if err != nil {
+		return meter.Bytes32{}, err
+	}
+	return c.sendRaw(ctx, hexutil.Encode(data))
+}
+
+// SendRaw broadcasts a signed tx in 0x-prefixed hex of its RLP encoding as-is, e.g. from an
+// external signer, and returns its id. The raw tx is checked to decode to a tx first.
+func (c *Client) SendRaw(ctx context.Context, raw string) (meter.Bytes32, error) {
+	if _, err := tx.Decode(raw); err != nil {
+		return meter.Bytes32{}, fmt.Errorf("invalid raw tx: %w", err)
+	}
+	return c.sendRaw(ctx, raw)
+}
+
+func (c *Client) sendRaw(ctx context.Context, raw string) (meter.Bytes32, error) {
+	var res txID
+	if err := c.broadcast(ctx, "/transactions", &rawTx{raw}, &res); err != nil {
+		return meter.Bytes32{}, asRejectionError(err)
+	}
+	return res.ID, nil
+}
+
+// GetTransactionReceipt returns the receipt of tx.
+// It returns nil if the tx is not mined yet.
+func (c *Client) GetTransactionReceipt(ctx context.Context, id meter.Bytes32) (*Receipt, error) {
+	var r *Receipt
+	if err := c.httpGet(ctx, "/transactions/"+id.String()+"/receipt", nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// WaitForReceipt polls the receipt of tx until it's mined, or ctx is done.
+// It returns ctx.Err(), e.g. context.DeadlineExceeded, if ctx is done before the tx is mined,
+// and stops on the first request error.
+// DefaultPollInterval is used if pollInterval is not positive.
+func (c *Client) WaitForReceipt(ctx context.Context, id meter.Bytes32, pollInterval time.Duration) (*Receipt, error) {
+	return WaitForReceipt(ctx, c, id, pollInterval)
+}
+
+// WaitForReceipt is Client.WaitForReceipt against any backend.
+func WaitForReceipt(ctx context.Context, b Backend, id meter.Bytes32, pollInterval time.Duration) (*Receipt, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r, err := b.GetTransactionReceipt(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if r != nil {
+			return r, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
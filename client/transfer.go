@@ -0,0 +1,60 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// Transfer is a token transfer log.
+// Meta is nil for transfers embedded in a receipt.
+type Transfer struct {
+	Sender    meter.Address
+	Recipient meter.Address
+	Amount    *big.Int
+	Token     byte
+	Meta      *LogMeta
+}
+
+type transferJSON struct {
+	Sender    meter.Address         `json:"sender"`
+	Recipient meter.Address         `json:"recipient"`
+	Amount    *math.HexOrDecimal256 `json:"amount"`
+	Token     byte                  `json:"token"`
+	Meta      *LogMeta              `json:"meta,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *Transfer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&transferJSON{
+		t.Sender,
+		t.Recipient,
+		(*math.HexOrDecimal256)(t.Amount),
+		t.Token,
+		t.Meta,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	var tj transferJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+	*t = Transfer{
+		Sender:    tj.Sender,
+		Recipient: tj.Recipient,
+		Amount:    bigOrZero(tj.Amount),
+		Token:     tj.Token,
+		Meta:      tj.Meta,
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+// ValidationError lists every failed check of ValidateTransaction.
+type ValidationError struct {
+	Failures []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, f.Error())
+	}
+	return "invalid tx: " + strings.Join(msgs, "; ")
+}
+
+// ValidateTransaction checks whether the node would accept the tx, without broadcasting it.
+// It checks chain tag, signature, expiration, intrinsic gas, clause simulation and
+// the gas payer's energy at the best block.
+// A *ValidationError is returned if any check fails, while other errors come from requests.
+func (c *Client) ValidateTransaction(ctx context.Context, t *tx.Transaction) error {
+	return ValidateTransaction(ctx, c, t)
+}
+
+// ValidateTransaction is Client.ValidateTransaction against any backend, e.g. clienttest.MockBackend.
+func ValidateTransaction(ctx context.Context, b Backend, t *tx.Transaction) error {
+	var failures []error
+	fail := func(format string, args ...interface{}) {
+		failures = append(failures, fmt.Errorf(format, args...))
+	}
+
+	best, err := b.GetBestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if best == nil {
+		return errors.New("best block not found")
+	}
+	genesis, err := b.GetBlock(ctx, RevisionNumber(0))
+	if err != nil {
+		return err
+	}
+	if genesis != nil && genesis.ID[31] != t.ChainTag() {
+		fail("chain tag mismatch: expected %d, got %d", genesis.ID[31], t.ChainTag())
+	}
+
+	if err := t.Validate(); err != nil {
+		fail("%v", err)
+	}
+	if t.IsExpiredAt(best) {
+		fail("expired at block %d, best block is %d", t.ExpiresAtBlock(), best.Number)
+	}
+	if t.BlockRef().Number() > best.Number+1 {
+		fail("block ref %d is ahead of best block %d", t.BlockRef().Number(), best.Number)
+	}
+
+	intrinsicGas, err := t.IntrinsicGas()
+	if err != nil {
+		fail("%v", err)
+	} else if intrinsicGas > t.Gas() {
+		fail("intrinsic gas %d exceeds provided gas %d", intrinsicGas, t.Gas())
+	}
+
+	origin, err := t.Origin()
+	if err != nil {
+		fail("%v", err)
+	} else if origin == (meter.Address{}) {
+		fail("tx not signed")
+	} else {
+		results, err := b.Inspect(ctx, t.Clauses(), &origin, RevisionID(best.ID))
+		if err != nil {
+			return err
+		}
+		var vmGas uint64
+		for i, r := range results {
+			if r.Reverted {
+				fail("%v", newRevertError(i, r))
+			}
+			vmGas += r.GasUsed
+		}
+		if intrinsicGas+vmGas > t.Gas() {
+			fail("required gas %d exceeds provided gas %d", intrinsicGas+vmGas, t.Gas())
+		}
+	}
+
+	if payer, err := t.GasPayer(); err != nil {
+		fail("%v", err)
+	} else if payer != (meter.Address{}) {
+		if err := checkEnergy(ctx, b, t, payer, best); err != nil {
+			if _, ok := err.(*failure); !ok {
+				return err
+			}
+			failures = append(failures, err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return &ValidationError{failures}
+	}
+	return nil
+}
+
+// failure is a failed check, to tell apart from request errors.
+type failure struct {
+	msg string
+}
+
+func (f *failure) Error() string {
+	return f.msg
+}
+
+// checkEnergy checks whether payer has enough energy to pay the max gas fee of tx.
+func checkEnergy(ctx context.Context, b Backend, t *tx.Transaction, payer meter.Address, best *Block) error {
+	base, err := b.BaseGasPrice(ctx, RevisionID(best.ID))
+	if err != nil {
+		return err
+	}
+	acc, err := b.GetAccount(ctx, payer, RevisionID(best.ID))
+	if err != nil {
+		return err
+	}
+	fee := new(big.Int).SetUint64(t.Gas())
+	fee.Mul(fee, t.GasPrice(base))
+	if acc.Energy.Cmp(fee) < 0 {
+		return &failure{fmt.Sprintf("insufficient energy of %v: has %v, requires %v", payer, acc.Energy, fee)}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+module meter-go
+
+go 1.22
+
+require (
+	github.com/ethereum/go-ethereum v1.10.26
+	github.com/google/uuid v1.3.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.31.0
+	golang.org/x/time v0.5.0
+)
+
+require (
+	github.com/btcsuite/btcd/btcec/v2 v2.2.0 // indirect
+	github.com/deckarep/golang-set v1.8.0 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
+	github.com/go-stack/stack v1.8.1 // indirect
+	github.com/rjeczalik/notify v0.9.1 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+)
@@ -0,0 +1,29 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// GenerateAccount generates a random private key and its address, e.g. for throwaway accounts.
+func GenerateAccount() (*ecdsa.PrivateKey, meter.Address, error) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, meter.Address{}, err
+	}
+	return priv, AddressFromPrivateKey(priv), nil
+}
+
+// ExportHex returns the private key in hex without 0x, the format of TEST_PRIVATE_KEY.
+func ExportHex(priv *ecdsa.PrivateKey) string {
+	return hex.EncodeToString(crypto.FromECDSA(priv))
+}
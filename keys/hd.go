@@ -0,0 +1,142 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// CoinType is the BIP-44 coin type of meter accounts.
+	// Meter has no registered coin type, and its wallets use Ethereum's.
+	CoinType = 60
+	// DefaultDerivationPath is the derivation path of the first meter account.
+	DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+	hardenedOffset = 0x80000000
+)
+
+// KeyFromMnemonic derives a private key from BIP-39 mnemonic and passphrase,
+// along the BIP-32 path, e.g. m/44'/60'/0'/0/0.
+func KeyFromMnemonic(mnemonic, passphrase, path string) (*ecdsa.PrivateKey, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	key, _, err := deriveKey(seed, indexes)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(key)
+}
+
+// deriveKey derives the private key and chain code from seed along child indexes, per BIP-32.
+func deriveKey(seed []byte, indexes []uint32) ([]byte, []byte, error) {
+	key, chainCode := splitHMAC([]byte("Bitcoin seed"), seed)
+	if err := checkKey(key); err != nil {
+		return nil, nil, err
+	}
+	var err error
+	for _, index := range indexes {
+		if key, chainCode, err = deriveChild(key, chainCode, index); err != nil {
+			return nil, nil, err
+		}
+	}
+	return key, chainCode, nil
+}
+
+// AddressFromPrivateKey returns the account address of the private key.
+func AddressFromPrivateKey(priv *ecdsa.PrivateKey) meter.Address {
+	return meter.Address(crypto.PubkeyToAddress(priv.PublicKey))
+}
+
+// parseDerivationPath parses path like m/44'/60'/0'/0/0 into child indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") {
+			part = strings.TrimSuffix(part, "'")
+			offset = hardenedOffset
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || n >= hardenedOffset {
+			return nil, fmt.Errorf("invalid derivation path %q: bad component %q", path, part)
+		}
+		indexes = append(indexes, uint32(n)+offset)
+	}
+	return indexes, nil
+}
+
+// deriveChild derives the child private key and chain code at index, per BIP-32.
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		priv, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], index)
+	data = append(data, b[:]...)
+
+	il, childChainCode := splitHMAC(chainCode, data)
+	n := crypto.S256().Params().N
+	k := new(big.Int).SetBytes(il)
+	if k.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+	k.Add(k, new(big.Int).SetBytes(key))
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+	child := make([]byte, 32)
+	k.FillBytes(child)
+	return child, childChainCode, nil
+}
+
+// splitHMAC returns the two halves of HMAC-SHA512(key, data).
+func splitHMAC(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// checkKey checks k is a valid secp256k1 private key.
+func checkKey(k []byte) error {
+	i := new(big.Int).SetBytes(k)
+	if i.Sign() == 0 || i.Cmp(crypto.S256().Params().N) >= 0 {
+		return errors.New("invalid derived key")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"meter-go/meter"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestDeriveKeyBIP32 checks the private keys and chain codes of test vector 1 of BIP-32.
+func TestDeriveKeyBIP32(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path      string
+		key       string
+		chainCode string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea", "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368", "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca", "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f"},
+		{"m/0'/1/2'/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4", "cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8", "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e"},
+	}
+	for _, tt := range tests {
+		indexes, err := parseDerivationPath(tt.path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		key, chainCode, err := deriveKey(seed, indexes)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if got := hex.EncodeToString(key); got != tt.key {
+			t.Errorf("%s: key = %s, want %s", tt.path, got, tt.key)
+		}
+		if got := hex.EncodeToString(chainCode); got != tt.chainCode {
+			t.Errorf("%s: chain code = %s, want %s", tt.path, got, tt.chainCode)
+		}
+	}
+}
+
+func TestKeyFromMnemonic(t *testing.T) {
+	tests := []struct {
+		path    string
+		key     string
+		address string
+	}{
+		// the well known first account of the test mnemonic in Ethereum wallets
+		{DefaultDerivationPath, "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", "0x9858effd232b4033e47d90003d41ec34ecaeda94"},
+		{"m/44'/60'/0'/0/1", "9a983cb3d832fbde5ab49d692b7a8bf5b5d232479c99333d0fc8e1d21f1b55b6", "0x6fac4d18c912343bf86fa7049364dd4e424ab9c0"},
+		// coin type 818, as used by VeChain wallets
+		{"m/44'/818'/0'/0/0", "307f098332f41fe361243bcfb6b5605bf7c31052c3cde7a57434cd3199c7f6c0", "0xe5d846748409df0b23d5bd47ceb14afc1cbbbcb3"},
+	}
+	for _, tt := range tests {
+		priv, err := KeyFromMnemonic(testMnemonic, "", tt.path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if got := hex.EncodeToString(priv.D.FillBytes(make([]byte, 32))); got != tt.key {
+			t.Errorf("%s: key = %s, want %s", tt.path, got, tt.key)
+		}
+		if got := AddressFromPrivateKey(priv); got != meter.MustParseAddress(tt.address) {
+			t.Errorf("%s: address = %v, want %s", tt.path, got, tt.address)
+		}
+	}
+
+	// the passphrase is part of the seed
+	withPass, err := KeyFromMnemonic(testMnemonic, "TREZOR", DefaultDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if AddressFromPrivateKey(withPass) == meter.MustParseAddress(tests[0].address) {
+		t.Error("passphrase ignored")
+	}
+
+	if _, err := KeyFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", DefaultDerivationPath); err == nil {
+		t.Error("expected error for bad mnemonic checksum")
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	indexes, err := parseDerivationPath("m/44'/60'/0'/0/2147483647")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{0x8000002c, 0x8000003c, 0x80000000, 0, 0x7fffffff}
+	if len(indexes) != len(want) {
+		t.Fatalf("got %v, want %v", indexes, want)
+	}
+	for i := range want {
+		if indexes[i] != want[i] {
+			t.Fatalf("got %v, want %v", indexes, want)
+		}
+	}
+
+	for _, path := range []string{
+		"",
+		"M/0",
+		"44'/60'",
+		"m/",
+		"m//0",
+		"m/a",
+		"m/-1",
+		"m/0''",
+		"m/0h",
+		"m/2147483648",
+		"m/2147483648'",
+		"m/4294967296",
+	} {
+		if _, err := parseDerivationPath(path); err == nil {
+			t.Errorf("%q: expected error", path)
+		}
+		if _, err := KeyFromMnemonic(testMnemonic, "", path); err == nil {
+			t.Errorf("%q: KeyFromMnemonic expected error", path)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package keys implements helpers to manage private keys of meter accounts.
+package keys
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// LoadKeystore decrypts a private key from keystore json in Web3 Secret Storage (v3) format.
+func LoadKeystore(json []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	key, err := keystore.DecryptKey(json, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey, nil
+}
+
+// SaveKeystore encrypts a private key into keystore json in Web3 Secret Storage (v3) format,
+// using the standard scrypt parameters.
+func SaveKeystore(priv *ecdsa.PrivateKey, passphrase string) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("nil private key")
+	}
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(priv.PublicKey),
+		PrivateKey: priv,
+	}
+	return keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MessageHash returns the hash signed by SignMessage.
+// It matches Ethereum's personal_sign, i.e. keccak256("\x19Ethereum Signed Message:\n" + len(msg) + msg),
+// since meter wallets like MetaMask sign messages that way.
+func MessageHash(msg []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(msg))
+	return meter.Keccak256([]byte(prefix), msg).Bytes()
+}
+
+// SignMessage signs msg for off-chain authentication, and returns the 65 bytes [R || S || V] signature,
+// where V is 27 or 28.
+func SignMessage(msg []byte, priv *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := crypto.Sign(MessageHash(msg), priv)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverMessageSigner recovers the address which signed msg. V of sig can be 0/1 or 27/28.
+func RecoverMessageSigner(msg, sig []byte) (meter.Address, error) {
+	if len(sig) != 65 {
+		return meter.Address{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	cpy := append([]byte(nil), sig...)
+	if cpy[64] >= 27 {
+		cpy[64] -= 27
+	}
+	if cpy[64] > 1 {
+		return meter.Address{}, errors.New("invalid signature recovery id")
+	}
+	pub, err := crypto.SigToPub(MessageHash(msg), cpy)
+	if err != nil {
+		return meter.Address{}, err
+	}
+	return meter.Address(crypto.PubkeyToAddress(*pub)), nil
+}
@@ -7,13 +7,18 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
 	"os"
+	"time"
 
+	"meter-go/client"
+	"meter-go/keys"
 	"meter-go/meter"
 	"meter-go/tx"
 
@@ -29,47 +34,63 @@ type RawTx struct {
 var (
 	ToAddress      = meter.MustParseAddress("0xf3dd5c55b96889369f714143f213403464a268a6")
 	TestPrivateKey = os.Getenv("TEST_PRIVATE_KEY") //  hex string without leading 0x
+	KeystoreFile   = os.Getenv("KEYSTORE_FILE")    // encrypted key file, preferred over TEST_PRIVATE_KEY
+	KeystorePass   = os.Getenv("KEYSTORE_PASSPHRASE")
 )
 
-func sendTx(blockRef uint32) {
-	chainTag := byte(88) // chainTag is NOT the same across chains
-	var expiration = uint32(100)
+// loadPrivateKey loads the private key from the keystore file if given, or TEST_PRIVATE_KEY.
+func loadPrivateKey() (*ecdsa.PrivateKey, error) {
+	if KeystoreFile != "" {
+		data, err := ioutil.ReadFile(KeystoreFile)
+		if err != nil {
+			return nil, err
+		}
+		return keys.LoadKeystore(data, KeystorePass)
+	}
+	return crypto.HexToECDSA(TestPrivateKey)
+}
+
+func sendTx(c *client.Client, params tx.ChainParams, blockRef tx.BlockRef) {
 	var gas = uint64(21000)
-	clause := tx.NewClause(&ToAddress).
-		WithValue(big.NewInt(2e18)).   // value in Wei
-		WithToken(byte(tx.MeterToken)) // choose which token to send
-
-	tx := new(tx.Builder).
-		BlockRef(tx.NewBlockRef(blockRef)).
-		ChainTag(chainTag).
-		Expiration(expiration).
+	clause := tx.TransferMTR(ToAddress, tx.MTR(2)) // use TransferMTRG to send MTRG
+
+	trx := new(tx.Builder).
+		ChainParams(params). // chainTag is NOT the same across chains
+		BlockRef(blockRef).
+		ExpireAfter(200 * time.Second).
 		GasPriceCoef(128).
 		Gas(gas).
 		Clause(clause).
-		Nonce(1234567).
-		Build()
-	privKey, err := crypto.HexToECDSA(TestPrivateKey)
+		Build() // nonce is filled randomly
+
+	price, err := c.EffectiveGasPrice(context.Background(), trx)
+	if err != nil {
+		fmt.Println("could not get gas price:", err)
+		return
+	}
+	fmt.Println("Gas price:", price, "max fee:", new(big.Int).Mul(price, new(big.Int).SetUint64(gas)))
+
+	privKey, err := loadPrivateKey()
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	sig, err := crypto.Sign(tx.SigningHash().Bytes(), privKey)
+	trx, err = trx.SignWith(tx.NewPrivateKeySigner(privKey))
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	tx = tx.WithSignature(sig)
-	rlpTx, err := rlp.EncodeToBytes(tx)
+	rlpTx, err := rlp.EncodeToBytes(trx)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	fmt.Println("Built Tx: ", tx.String())
+	fmt.Println("Built Tx: ", trx.String())
 	fmt.Println("Raw Tx:", hexutil.Encode(rlpTx))
 
 	fmt.Println("Send tx to warringstakes network")
-	res := httpPost("http://warringstakes.meter.io:8669/transactions", RawTx{Raw: hexutil.Encode(rlpTx)})
+	res := httpPost(client.NetworkTestnet.BaseURL()+"/transactions", RawTx{Raw: hexutil.Encode(rlpTx)})
 	fmt.Println("Received response: ", string(res))
 	var txObj map[string]string
 	if err = json.Unmarshal(res, &txObj); err != nil {
@@ -123,7 +144,26 @@ func getBestBlock(url string) *ApiBlock {
 }
 
 func main() {
-	bestBlock := getBestBlock("http://warringstakes.meter.io:8669/blocks/best")
+	bestBlock := getBestBlock(client.NetworkTestnet.BaseURL() + "/blocks/best")
+	if bestBlock == nil {
+		return
+	}
+	blockID, err := meter.ParseBytes32(bestBlock.ID)
+	if err != nil {
+		fmt.Println("invalid block id:", err)
+		return
+	}
+
+	c, err := client.Testnet()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	params, err := c.Params(context.Background())
+	if err != nil {
+		fmt.Println("could not get chain params:", err)
+		return
+	}
 
-	sendTx(bestBlock.Number)
+	sendTx(c, params, tx.NewBlockRefFromID(blockID))
 }
@@ -6,9 +6,10 @@
 package meter
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
-	"errors"
-	"strings"
+	"encoding/json"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -26,26 +27,65 @@ func (a Address) String() string {
 	return "0x" + hex.EncodeToString(a[:])
 }
 
+// ChecksumString returns the EIP-55 mixed-case checksummed form of address.
+func (a Address) ChecksumString() string {
+	return common.Address(a).Hex()
+}
+
+// Short returns the compact form for display, e.g. 0x1234…abcd.
+func (a Address) Short() string {
+	h := hex.EncodeToString(a[:])
+	return "0x" + h[:4] + "…" + h[len(h)-4:]
+}
+
 // Bytes returns byte slice form of address.
 func (a Address) Bytes() []byte {
 	return a[:]
 }
 
-// ParseAddress convert string presented address into Address type.
-func ParseAddress(s string) (Address, error) {
-	if len(s) == AddressLength*2 {
-	} else if len(s) == AddressLength*2+2 {
-		if strings.ToLower(s[:2]) != "0x" {
-			return Address{}, errors.New("invalid prefix")
-		}
-		s = s[2:]
-	} else {
-		return Address{}, errors.New("invalid length")
+// EqualConstantTime compares two Address values in constant time, for comparisons
+// on attacker controlled input like signature verification. Use == otherwise.
+func (a Address) EqualConstantTime(o Address) bool {
+	return subtle.ConstantTimeCompare(a[:], o[:]) == 1
+}
+
+// GobEncode implements gob.GobEncoder, using raw bytes.
+func (a Address) GobEncode() ([]byte, error) {
+	return a.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (a *Address) GobDecode(data []byte) error {
+	if len(data) != AddressLength {
+		return fmt.Errorf("gob decode address: %w: expected %d bytes, got %d", ErrInvalidLength, AddressLength, len(data))
 	}
+	copy(a[:], data)
+	return nil
+}
 
-	var addr Address
-	_, err := hex.Decode(addr[:], []byte(s))
+// MarshalJSON implements json.Marshaler.
+func (a Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Address) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseAddress(s)
 	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// ParseAddress convert string presented address into Address type.
+func ParseAddress(s string) (Address, error) {
+	var addr Address
+	if err := parseFixedHex("address", s, addr[:]); err != nil {
 		return Address{}, err
 	}
 	return addr, nil
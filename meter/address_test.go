@@ -0,0 +1,47 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package meter
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestAddressGob(t *testing.T) {
+	type wrapper struct {
+		Value Address
+		Ptr   *Address
+	}
+	v := MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	in := wrapper{v, &v}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out wrapper
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Value != v || out.Ptr == nil || *out.Ptr != v {
+		t.Fatalf("got %v, %v, want %v", out.Value, out.Ptr, v)
+	}
+}
+
+func TestAddressGobDecodeInvalidLength(t *testing.T) {
+	for _, n := range []int{0, 1, AddressLength - 1, AddressLength + 1} {
+		var v Address
+		err := v.GobDecode(make([]byte, n))
+		if !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("%d bytes: got %v, want ErrInvalidLength", n, err)
+		}
+		if v != (Address{}) {
+			t.Errorf("%d bytes: value modified on error", n)
+		}
+	}
+}
@@ -6,9 +6,12 @@
 package meter
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"strings"
+	"fmt"
+	"math/big"
 )
 
 // Bytes32 array of 32 bytes.
@@ -19,26 +22,79 @@ func (b Bytes32) String() string {
 	return "0x" + hex.EncodeToString(b[:])
 }
 
+// Short returns the compact form for display, e.g. 0x1234…abcd.
+func (b Bytes32) Short() string {
+	h := hex.EncodeToString(b[:])
+	return "0x" + h[:4] + "…" + h[len(h)-4:]
+}
+
 // Bytes returns byte slice form of Bytes32.
 func (b Bytes32) Bytes() []byte {
 	return b[:]
 }
 
-// ParseBytes32 convert string presented into Bytes32 type
-func ParseBytes32(s string) (Bytes32, error) {
-	if len(s) == 32*2 {
-	} else if len(s) == 32*2+2 {
-		if strings.ToLower(s[:2]) != "0x" {
-			return Bytes32{}, errors.New("invalid prefix")
-		}
-		s = s[2:]
-	} else {
-		return Bytes32{}, errors.New("invalid length")
-	}
+// Big returns the big-endian unsigned integer value of Bytes32.
+func (b Bytes32) Big() *big.Int {
+	return new(big.Int).SetBytes(b[:])
+}
 
+// Bytes32FromBig converts a non-negative big.Int into Bytes32 with left padding.
+func Bytes32FromBig(i *big.Int) (Bytes32, error) {
+	if i == nil || i.Sign() < 0 {
+		return Bytes32{}, errors.New("negative or nil value")
+	}
+	if i.BitLen() > 256 {
+		return Bytes32{}, errors.New("value exceeds 32 bytes")
+	}
 	var b Bytes32
-	_, err := hex.Decode(b[:], []byte(s))
+	bs := i.Bytes()
+	copy(b[32-len(bs):], bs)
+	return b, nil
+}
+
+// EqualConstantTime compares two Bytes32 values in constant time, for comparisons
+// on attacker controlled input like signature verification. Use == otherwise.
+func (b Bytes32) EqualConstantTime(o Bytes32) bool {
+	return subtle.ConstantTimeCompare(b[:], o[:]) == 1
+}
+
+// GobEncode implements gob.GobEncoder, using raw bytes.
+func (b Bytes32) GobEncode() ([]byte, error) {
+	return b.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (b *Bytes32) GobDecode(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("gob decode bytes32: %w: expected %d bytes, got %d", ErrInvalidLength, 32, len(data))
+	}
+	copy(b[:], data)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bytes32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bytes32) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseBytes32(s)
 	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// ParseBytes32 convert string presented into Bytes32 type
+func ParseBytes32(s string) (Bytes32, error) {
+	var b Bytes32
+	if err := parseFixedHex("bytes32", s, b[:]); err != nil {
 		return Bytes32{}, err
 	}
 	return b, nil
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package meter
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestBytes32Gob(t *testing.T) {
+	type wrapper struct {
+		Value Bytes32
+		Ptr   *Bytes32
+	}
+	v := MustParseBytes32("0x4f833920baf63d77202d977c28b7d9c6d27224033b3c84ffcfdb9e40404aef76")
+	in := wrapper{v, &v}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out wrapper
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Value != v || out.Ptr == nil || *out.Ptr != v {
+		t.Fatalf("got %v, %v, want %v", out.Value, out.Ptr, v)
+	}
+}
+
+func TestBytes32GobDecodeInvalidLength(t *testing.T) {
+	for _, n := range []int{0, 1, 32 - 1, 32 + 1} {
+		var v Bytes32
+		err := v.GobDecode(make([]byte, n))
+		if !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("%d bytes: got %v, want ErrInvalidLength", n, err)
+		}
+		if v != (Bytes32{}) {
+			t.Errorf("%d bytes: value modified on error", n)
+		}
+	}
+}
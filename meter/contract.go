@@ -0,0 +1,22 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package meter
+
+import "encoding/binary"
+
+// CreateContractAddress returns the address of a contract created by a clause.
+// As the node derives it from the tx rather than the origin's nonce, the address is
+// blake2b(txID, clauseIndex, creationCount)[12:], where the indexes are 4-byte big-endian,
+// and creationCount is the count of contracts created before by the same clause,
+// i.e. 0 for the contract deployed by the clause itself.
+func CreateContractAddress(txID Bytes32, clauseIndex uint32, creationCount uint32) (addr Address) {
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], clauseIndex)
+	binary.BigEndian.PutUint32(b[4:], creationCount)
+	h := Blake2b(txID[:], b[:])
+	copy(addr[:], h[12:])
+	return
+}
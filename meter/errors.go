@@ -0,0 +1,51 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package meter
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned when parsing hex presented values, match them with errors.Is.
+var (
+	ErrInvalidLength = errors.New("invalid length")
+	ErrInvalidPrefix = errors.New("invalid prefix")
+	ErrInvalidHex    = errors.New("invalid hex")
+)
+
+// maxPreviewLength limits the input echoed in parse errors.
+const maxPreviewLength = 16
+
+// preview returns truncated s for error messages.
+func preview(s string) string {
+	if len(s) > maxPreviewLength {
+		return s[:maxPreviewLength] + "..."
+	}
+	return s
+}
+
+// parseFixedHex decodes s, with optional 0x prefix, into out, which must be fully filled.
+func parseFixedHex(typeName string, s string, out []byte) error {
+	input := s
+	if len(s) == len(out)*2 {
+	} else if len(s) == len(out)*2+2 {
+		if strings.ToLower(s[:2]) != "0x" {
+			return fmt.Errorf("parse %v %q: %w: expected 0x", typeName, preview(input), ErrInvalidPrefix)
+		}
+		s = s[2:]
+	} else {
+		return fmt.Errorf("parse %v %q: %w: expected %d hex chars, got %d",
+			typeName, preview(input), ErrInvalidLength, len(out)*2, len(strings.TrimPrefix(strings.ToLower(s), "0x")))
+	}
+
+	if _, err := hex.Decode(out, []byte(s)); err != nil {
+		return fmt.Errorf("parse %v %q: %w: %v", typeName, preview(input), ErrInvalidHex, err)
+	}
+	return nil
+}
@@ -9,9 +9,14 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/crypto/blake2b"
 )
 
+// Hashes used on meter:
+//   - Blake2b for tx signing hash, tx id and other chain structures inherited from Thor
+//   - Keccak256 for EVM things, e.g. ABI selectors, event topics and account addresses
+
 // NewBlake2b return blake2b-256 hash.
 func NewBlake2b() hash.Hash {
 	hash, err := blake2b.New256(nil)
@@ -34,3 +39,8 @@ func Blake2b(data ...[]byte) (b32 Bytes32) {
 	hash.Sum(b32[:0])
 	return
 }
+
+// Keccak256 computes keccak256 checksum for given data, as the EVM does.
+func Keccak256(data ...[]byte) Bytes32 {
+	return Bytes32(crypto.Keccak256Hash(data...))
+}
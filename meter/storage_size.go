@@ -13,15 +13,32 @@ import (
 // StorageSize describes storage size in bytes.
 type StorageSize int64
 
+// Storage size units.
+const (
+	KiB StorageSize = 1 << (10 * (iota + 1))
+	MiB
+	GiB
+	TiB
+)
+
+// String implements the stringer interface, e.g. "512.00 B", "1.50 KiB".
 func (ss StorageSize) String() string {
-	if ss > 1000000000 {
-		return fmt.Sprintf("%.2f gB", float64(ss)/1000000000)
-	} else if ss > 1000000 {
-		return fmt.Sprintf("%.2f mB", float64(ss)/1000000)
-	} else if ss > 1000 {
-		return fmt.Sprintf("%.2f kB", float64(ss)/1000)
+	switch {
+	case ss >= TiB:
+		return fmt.Sprintf("%.2f TiB", float64(ss)/float64(TiB))
+	case ss >= GiB:
+		return fmt.Sprintf("%.2f GiB", float64(ss)/float64(GiB))
+	case ss >= MiB:
+		return fmt.Sprintf("%.2f MiB", float64(ss)/float64(MiB))
+	case ss >= KiB:
+		return fmt.Sprintf("%.2f KiB", float64(ss)/float64(KiB))
 	}
-	return fmt.Sprintf("%d B", ss)
+	return fmt.Sprintf("%.2f B", float64(ss))
+}
+
+// Bytes returns size in bytes as float64.
+func (ss StorageSize) Bytes() float64 {
+	return float64(ss)
 }
 
 // Int64 returns int64 value.
@@ -0,0 +1,173 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package mscript encodes and decodes Meter script data, which is carried by clause data to invoke
+// the builtin modules like staking and auction, rather than EVM contracts.
+//
+// Script data is laid out as
+//
+//	0xffffffff || 0xdeadbeef || rlp([[version, moduleID], payload])
+//
+// where the leading 0xffffffff makes it an invalid method selector, and the payload is the RLP
+// encoded body of the module, starting with the opcode.
+//
+// Staking operations have typed bodies, e.g. Bound and Unbound, which encode into clause data.
+package mscript
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	scriptPrefix  = []byte{0xff, 0xff, 0xff, 0xff}
+	scriptPattern = []byte{0xde, 0xad, 0xbe, 0xef}
+
+	// ErrNotScript is returned when the data is not Meter script data.
+	ErrNotScript = errors.New("not script data")
+)
+
+// ModuleID identifies a builtin module.
+type ModuleID uint32
+
+// Builtin modules.
+const (
+	StakingModule     ModuleID = 1000
+	AuctionModule     ModuleID = 1001
+	AccountLockModule ModuleID = 1002
+)
+
+// String returns the module name.
+func (m ModuleID) String() string {
+	switch m {
+	case StakingModule:
+		return "staking"
+	case AuctionModule:
+		return "auction"
+	case AccountLockModule:
+		return "accountlock"
+	}
+	return fmt.Sprintf("module(%d)", uint32(m))
+}
+
+// Opcode is the operation of a module.
+type Opcode uint32
+
+// Opcodes of the staking module.
+const (
+	OpBound           Opcode = 1
+	OpUnbound         Opcode = 2
+	OpCandidate       Opcode = 3
+	OpUncandidate     Opcode = 4
+	OpDelegate        Opcode = 5
+	OpUndelegate      Opcode = 6
+	OpCandidateUpdate Opcode = 7
+	OpBucketUpdate    Opcode = 8
+)
+
+var stakingOpNames = map[Opcode]string{
+	OpBound:           "bound",
+	OpUnbound:         "unbound",
+	OpCandidate:       "candidate",
+	OpUncandidate:     "uncandidate",
+	OpDelegate:        "delegate",
+	OpUndelegate:      "undelegate",
+	OpCandidateUpdate: "candidate update",
+	OpBucketUpdate:    "bucket update",
+}
+
+type header struct {
+	Version uint32
+	ModID   uint32
+}
+
+type script struct {
+	Header  header
+	Payload []byte
+}
+
+// ScriptOp is a decoded script operation.
+type ScriptOp struct {
+	Module  ModuleID
+	Version uint32
+	Opcode  Opcode
+	Payload []byte // the RLP encoded body of the module
+}
+
+// Name returns the readable name of the operation, e.g. "staking bound".
+func (op *ScriptOp) Name() string {
+	if op.Module == StakingModule {
+		if name, ok := stakingOpNames[op.Opcode]; ok {
+			return "staking " + name
+		}
+	}
+	return fmt.Sprintf("%v op(%d)", op.Module, uint32(op.Opcode))
+}
+
+// IsScriptData returns whether the clause data is Meter script data.
+func IsScriptData(data []byte) bool {
+	n := len(scriptPrefix) + len(scriptPattern)
+	return len(data) > n &&
+		bytes.Equal(data[:len(scriptPrefix)], scriptPrefix) &&
+		bytes.Equal(data[len(scriptPrefix):n], scriptPattern)
+}
+
+// DecodeScriptData decodes the script operation from clause data.
+// It returns ErrNotScript if the data is not script data.
+func DecodeScriptData(data []byte) (*ScriptOp, error) {
+	if !IsScriptData(data) {
+		return nil, ErrNotScript
+	}
+	var s script
+	if err := rlp.DecodeBytes(data[len(scriptPrefix)+len(scriptPattern):], &s); err != nil {
+		return nil, fmt.Errorf("invalid script data: %w", err)
+	}
+	opcode, err := decodeOpcode(s.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script payload: %w", err)
+	}
+	return &ScriptOp{
+		Module:  ModuleID(s.Header.ModID),
+		Version: s.Header.Version,
+		Opcode:  opcode,
+		Payload: s.Payload,
+	}, nil
+}
+
+// EncodeScriptData encodes the RLP encoded module body into clause data.
+// The body must be a list starting with the opcode.
+func EncodeScriptData(module ModuleID, version uint32, payload []byte) ([]byte, error) {
+	if _, err := decodeOpcode(payload); err != nil {
+		return nil, fmt.Errorf("invalid script payload: %w", err)
+	}
+	enc, err := rlp.EncodeToBytes(&script{header{version, uint32(module)}, payload})
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, len(scriptPrefix)+len(scriptPattern)+len(enc))
+	data = append(data, scriptPrefix...)
+	data = append(data, scriptPattern...)
+	return append(data, enc...), nil
+}
+
+// decodeOpcode decodes the opcode, which is the first element of the payload list.
+func decodeOpcode(payload []byte) (Opcode, error) {
+	s := rlp.NewStream(bytes.NewReader(payload), uint64(len(payload)))
+	if _, err := s.List(); err != nil {
+		return 0, err
+	}
+	opcode, err := s.Uint()
+	if err != nil {
+		return 0, err
+	}
+	if opcode > math.MaxUint32 {
+		return 0, errors.New("opcode overflow")
+	}
+	return Opcode(opcode), nil
+}
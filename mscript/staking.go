@@ -0,0 +1,266 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package mscript
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// StakingVersion is the script version of staking operations.
+const StakingVersion = 0
+
+// StakingBody is the payload of the staking module, shared by all its operations.
+// Fields not used by an operation are left zero.
+type StakingBody struct {
+	Opcode          Opcode
+	Version         uint32
+	Option          uint32 // lock option of bound, or candidate option
+	HolderAddr      meter.Address
+	CandAddr        meter.Address
+	CandName        []byte
+	CandDescription []byte
+	CandPubKey      []byte
+	CandIP          []byte
+	CandPort        uint16
+	StakingID       meter.Bytes32 // the bucket id
+	Amount          *big.Int
+	Token           tx.TokenType
+	Autobid         uint8 // autobid percentage
+	Timestamp       uint64
+	Nonce           uint64
+	ExtraData       []byte
+}
+
+// Encode encodes the body into clause data to the staking module.
+func (b *StakingBody) Encode() ([]byte, error) {
+	body := *b
+	if body.Amount == nil {
+		body.Amount = new(big.Int)
+	}
+	payload, err := rlp.EncodeToBytes(&body)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeScriptData(StakingModule, StakingVersion, payload)
+}
+
+// DecodeStakingBody decodes the staking body from clause data.
+// It returns ErrNotScript if the data is not script data.
+func DecodeStakingBody(data []byte) (*StakingBody, error) {
+	op, err := DecodeScriptData(data)
+	if err != nil {
+		return nil, err
+	}
+	return op.StakingBody()
+}
+
+// StakingBody decodes the payload of a staking operation.
+func (op *ScriptOp) StakingBody() (*StakingBody, error) {
+	if op.Module != StakingModule {
+		return nil, fmt.Errorf("not a staking operation: %v", op.Module)
+	}
+	var b StakingBody
+	if err := rlp.DecodeBytes(op.Payload, &b); err != nil {
+		return nil, fmt.Errorf("invalid staking body: %w", err)
+	}
+	return &b, nil
+}
+
+// decodeStakingOp decodes the staking body of clause data, which must be of opcode.
+func decodeStakingOp(data []byte, opcode Opcode) (*StakingBody, error) {
+	b, err := DecodeStakingBody(data)
+	if err != nil {
+		return nil, err
+	}
+	if b.Opcode != opcode {
+		return nil, fmt.Errorf("opcode mismatch: expected %s, got %d", stakingOpNames[opcode], uint32(b.Opcode))
+	}
+	return b, nil
+}
+
+// Bound locks Amount of the holder into a new bucket, optionally voting for Candidate.
+type Bound struct {
+	Holder    meter.Address
+	Candidate meter.Address // zero for no candidate
+	Amount    *big.Int
+	Token     tx.TokenType
+	Option    uint32 // lock option
+	Autobid   uint8
+	Timestamp uint64
+	Nonce     uint64
+}
+
+// Encode encodes the operation into clause data.
+func (o *Bound) Encode() ([]byte, error) {
+	if o.Amount == nil || o.Amount.Sign() <= 0 {
+		return nil, errors.New("bound amount must be positive")
+	}
+	return (&StakingBody{
+		Opcode:     OpBound,
+		Option:     o.Option,
+		HolderAddr: o.Holder,
+		CandAddr:   o.Candidate,
+		Amount:     o.Amount,
+		Token:      o.Token,
+		Autobid:    o.Autobid,
+		Timestamp:  o.Timestamp,
+		Nonce:      o.Nonce,
+	}).Encode()
+}
+
+// DecodeBound decodes a bound operation from clause data.
+func DecodeBound(data []byte) (*Bound, error) {
+	b, err := decodeStakingOp(data, OpBound)
+	if err != nil {
+		return nil, err
+	}
+	return &Bound{
+		Holder:    b.HolderAddr,
+		Candidate: b.CandAddr,
+		Amount:    b.Amount,
+		Token:     b.Token,
+		Option:    b.Option,
+		Autobid:   b.Autobid,
+		Timestamp: b.Timestamp,
+		Nonce:     b.Nonce,
+	}, nil
+}
+
+// Unbound releases the bucket StakingID of the holder.
+type Unbound struct {
+	Holder    meter.Address
+	StakingID meter.Bytes32
+	Amount    *big.Int
+	Token     tx.TokenType
+	Timestamp uint64
+	Nonce     uint64
+}
+
+// Encode encodes the operation into clause data.
+func (o *Unbound) Encode() ([]byte, error) {
+	if o.StakingID == (meter.Bytes32{}) {
+		return nil, errors.New("unbound needs a staking id")
+	}
+	return (&StakingBody{
+		Opcode:     OpUnbound,
+		HolderAddr: o.Holder,
+		StakingID:  o.StakingID,
+		Amount:     o.Amount,
+		Token:      o.Token,
+		Timestamp:  o.Timestamp,
+		Nonce:      o.Nonce,
+	}).Encode()
+}
+
+// DecodeUnbound decodes an unbound operation from clause data.
+func DecodeUnbound(data []byte) (*Unbound, error) {
+	b, err := decodeStakingOp(data, OpUnbound)
+	if err != nil {
+		return nil, err
+	}
+	return &Unbound{
+		Holder:    b.HolderAddr,
+		StakingID: b.StakingID,
+		Amount:    b.Amount,
+		Token:     b.Token,
+		Timestamp: b.Timestamp,
+		Nonce:     b.Nonce,
+	}, nil
+}
+
+// Delegate votes the bucket StakingID of the holder for Candidate.
+type Delegate struct {
+	Holder    meter.Address
+	Candidate meter.Address
+	StakingID meter.Bytes32
+	Amount    *big.Int
+	Token     tx.TokenType
+	Timestamp uint64
+	Nonce     uint64
+}
+
+// Encode encodes the operation into clause data.
+func (o *Delegate) Encode() ([]byte, error) {
+	if o.StakingID == (meter.Bytes32{}) || o.Candidate == (meter.Address{}) {
+		return nil, errors.New("delegate needs a staking id and a candidate")
+	}
+	return (&StakingBody{
+		Opcode:     OpDelegate,
+		HolderAddr: o.Holder,
+		CandAddr:   o.Candidate,
+		StakingID:  o.StakingID,
+		Amount:     o.Amount,
+		Token:      o.Token,
+		Timestamp:  o.Timestamp,
+		Nonce:      o.Nonce,
+	}).Encode()
+}
+
+// DecodeDelegate decodes a delegate operation from clause data.
+func DecodeDelegate(data []byte) (*Delegate, error) {
+	b, err := decodeStakingOp(data, OpDelegate)
+	if err != nil {
+		return nil, err
+	}
+	return &Delegate{
+		Holder:    b.HolderAddr,
+		Candidate: b.CandAddr,
+		StakingID: b.StakingID,
+		Amount:    b.Amount,
+		Token:     b.Token,
+		Timestamp: b.Timestamp,
+		Nonce:     b.Nonce,
+	}, nil
+}
+
+// Undelegate withdraws the vote of the bucket StakingID of the holder.
+type Undelegate struct {
+	Holder    meter.Address
+	StakingID meter.Bytes32
+	Amount    *big.Int
+	Token     tx.TokenType
+	Timestamp uint64
+	Nonce     uint64
+}
+
+// Encode encodes the operation into clause data.
+func (o *Undelegate) Encode() ([]byte, error) {
+	if o.StakingID == (meter.Bytes32{}) {
+		return nil, errors.New("undelegate needs a staking id")
+	}
+	return (&StakingBody{
+		Opcode:     OpUndelegate,
+		HolderAddr: o.Holder,
+		StakingID:  o.StakingID,
+		Amount:     o.Amount,
+		Token:      o.Token,
+		Timestamp:  o.Timestamp,
+		Nonce:      o.Nonce,
+	}).Encode()
+}
+
+// DecodeUndelegate decodes an undelegate operation from clause data.
+func DecodeUndelegate(data []byte) (*Undelegate, error) {
+	b, err := decodeStakingOp(data, OpUndelegate)
+	if err != nil {
+		return nil, err
+	}
+	return &Undelegate{
+		Holder:    b.HolderAddr,
+		StakingID: b.StakingID,
+		Amount:    b.Amount,
+		Token:     b.Token,
+		Timestamp: b.Timestamp,
+		Nonce:     b.Nonce,
+	}, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package mscript
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+var (
+	testHolder    = meter.MustParseAddress("0xd989829d88b0ed1b06edf5c50174ecfa64f14a64")
+	testCandidate = meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	testStakingID = meter.MustParseBytes32("0x8e5bd8b3a0f1d7b56eb05fb75b5e9b5a52cdfd0e6de8d2f3b3ed66f08a1e9ed1")
+	testAmount, _ = new(big.Int).SetString("2000000000000000000000", 10)
+)
+
+// The expected clause data were assembled by an independent RLP encoder, field by field:
+//
+//	ffffffff deadbeef             script prefix and pattern
+//	f86f                          script list
+//	  c4 80 8203e8                header [version 0, module 1000]
+//	  b868 f866 ...               payload, the RLP encoded body as a byte string
+const (
+	boundData = "ffffffff" + "deadbeef" + "f86f" + "c4808203e8" + "b868" +
+		"f866" + "01" + "80" + "01" + // opcode, version, option
+		"94d989829d88b0ed1b06edf5c50174ecfa64f14a64" + // holder
+		"947567d83b7b8d80addcb281a71d54fc7b3364ffed" + // candidate
+		"80808080" + "80" + // name, description, pubkey, ip, port
+		"a00000000000000000000000000000000000000000000000000000000000000000" + // staking id
+		"896c6b935b8bbd400000" + "01" + "64" + // amount, token, autobid
+		"845f5e1000" + "07" + "80" // timestamp, nonce, extra data
+	unboundData = "ffffffff" + "deadbeef" + "f86f" + "c4808203e8" + "b868" +
+		"f866" + "02" + "80" + "80" +
+		"94d989829d88b0ed1b06edf5c50174ecfa64f14a64" +
+		"940000000000000000000000000000000000000000" +
+		"80808080" + "80" +
+		"a08e5bd8b3a0f1d7b56eb05fb75b5e9b5a52cdfd0e6de8d2f3b3ed66f08a1e9ed1" +
+		"896c6b935b8bbd400000" + "01" + "80" +
+		"845f5e1064" + "08" + "80"
+)
+
+func TestBound(t *testing.T) {
+	bound := &Bound{
+		Holder:    testHolder,
+		Candidate: testCandidate,
+		Amount:    testAmount,
+		Token:     tx.MeterGovToken,
+		Option:    1,
+		Autobid:   100,
+		Timestamp: 1600000000,
+		Nonce:     7,
+	}
+	data, err := bound.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != boundData {
+		t.Errorf("encoded =\n%s\nwant\n%s", got, boundData)
+	}
+
+	want, _ := hex.DecodeString(boundData)
+	decoded, err := DecodeBound(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, bound) {
+		t.Errorf("decoded %+v, want %+v", decoded, bound)
+	}
+
+	op, err := DecodeScriptData(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Module != StakingModule || op.Opcode != OpBound || op.Name() != "staking bound" {
+		t.Errorf("unexpected op %+v", op)
+	}
+
+	if _, err := (&Bound{Holder: testHolder}).Encode(); err == nil {
+		t.Error("expected error for no amount")
+	}
+}
+
+func TestUnbound(t *testing.T) {
+	unbound := &Unbound{
+		Holder:    testHolder,
+		StakingID: testStakingID,
+		Amount:    testAmount,
+		Token:     tx.MeterGovToken,
+		Timestamp: 1600000100,
+		Nonce:     8,
+	}
+	data, err := unbound.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != unboundData {
+		t.Errorf("encoded =\n%s\nwant\n%s", got, unboundData)
+	}
+	decoded, err := DecodeUnbound(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, unbound) {
+		t.Errorf("decoded %+v, want %+v", decoded, unbound)
+	}
+
+	// the opcode must match the typed operation
+	if _, err := DecodeBound(data); err == nil {
+		t.Error("expected opcode mismatch")
+	}
+	if _, err := (&Unbound{Holder: testHolder}).Encode(); err == nil {
+		t.Error("expected error for no staking id")
+	}
+}
+
+func TestDelegateRoundTrip(t *testing.T) {
+	delegate := &Delegate{
+		Holder:    testHolder,
+		Candidate: testCandidate,
+		StakingID: testStakingID,
+		Amount:    testAmount,
+		Token:     tx.MeterGovToken,
+		Timestamp: 1600000200,
+		Nonce:     9,
+	}
+	data, err := delegate.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeDelegate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, delegate) {
+		t.Errorf("decoded %+v, want %+v", decoded, delegate)
+	}
+
+	undelegate := &Undelegate{
+		Holder:    testHolder,
+		StakingID: testStakingID,
+		Amount:    testAmount,
+		Token:     tx.MeterGovToken,
+		Timestamp: 1600000300,
+		Nonce:     10,
+	}
+	if data, err = undelegate.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	decodedUndelegate, err := DecodeUndelegate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decodedUndelegate, undelegate) {
+		t.Errorf("decoded %+v, want %+v", decodedUndelegate, undelegate)
+	}
+}
+
+func TestDecodeStakingBody(t *testing.T) {
+	if _, err := DecodeStakingBody([]byte{0xa9, 0x05, 0x9c, 0xbb}); !errors.Is(err, ErrNotScript) {
+		t.Errorf("got %v, want ErrNotScript", err)
+	}
+
+	// other modules are not staking
+	payload, _ := hex.DecodeString("c101")
+	data, err := EncodeScriptData(AuctionModule, 0, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeStakingBody(data); err == nil {
+		t.Error("expected error for auction module")
+	}
+
+	// a truncated body
+	valid, _ := hex.DecodeString(boundData)
+	op, err := DecodeScriptData(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err = EncodeScriptData(StakingModule, 0, op.Payload[:20]); err == nil {
+		t.Fatalf("expected error for truncated payload, got %x", data)
+	}
+	op.Payload = bytes.Clone(op.Payload[:len(op.Payload)-5])
+	if _, err := op.StakingBody(); err == nil {
+		t.Error("expected error for truncated body")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package testvectors provides known-answer vectors of tx encoding and signing,
+// for checking other implementations against this one.
+package testvectors
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"meter-go/meter"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Vector is a fully specified tx with its expected outputs.
+type Vector struct {
+	Name        string
+	PrivateKey  string // hex without 0x, as TEST_PRIVATE_KEY
+	Origin      meter.Address
+	SigningHash meter.Bytes32
+	Signature   string // 0x-prefixed hex
+	Raw         string // 0x-prefixed hex of the signed tx
+	ID          meter.Bytes32
+
+	// Build builds the unsigned tx.
+	Build func() *tx.Transaction
+}
+
+var vectorTo = meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+
+// Vectors are the known-answer vectors.
+var Vectors = []*Vector{
+	{
+		Name:        "two clauses of MTR and MTRG",
+		PrivateKey:  "7582be841ca040aa940fff6c05773129e135623e41acce3e0b8ba520dc1ae26a",
+		Origin:      meter.MustParseAddress("0xd989829d88b0ed1b06edf5c50174ecfa64f14a64"),
+		SigningHash: meter.MustParseBytes32("0xa154f56a4611bd7b3129de43bef0dc046c0ac97b0b97d27a1fb5ea87bf7c5833"),
+		Signature:   "0x281834de48c099195aad659ed3ed3d8da9763a900d8a5f556e695586b9f99f73613e3071a427e933e2854f40f9d6997f64c877866bcb88a078f09e8090808f6e01",
+		Raw:         "0xf8990184aabbccdd20f842e0947567d83b7b8d80addcb281a71d54fc7b3364ffed8227108086000000606060e0947567d83b7b8d80addcb281a71d54fc7b3364ffed824e20018600000060606081808252088083bc614ec0b841281834de48c099195aad659ed3ed3d8da9763a900d8a5f556e695586b9f99f73613e3071a427e933e2854f40f9d6997f64c877866bcb88a078f09e8090808f6e01",
+		ID:          meter.MustParseBytes32("0x4f833920baf63d77202d977c28b7d9c6d27224033b3c84ffcfdb9e40404aef76"),
+		Build: func() *tx.Transaction {
+			data := []byte{0, 0, 0, 0x60, 0x60, 0x60}
+			return new(tx.Builder).
+				ChainTag(1).
+				BlockRef(tx.BlockRef{0, 0, 0, 0, 0xaa, 0xbb, 0xcc, 0xdd}).
+				Expiration(32).
+				Clause(tx.NewClause(&vectorTo).WithValue(big.NewInt(10000)).WithTokenType(tx.MeterToken).WithData(data)).
+				Clause(tx.NewClause(&vectorTo).WithValue(big.NewInt(20000)).WithTokenType(tx.MeterGovToken).WithData(data)).
+				GasPriceCoef(128).
+				Gas(21000).
+				Nonce(12345678).
+				Build()
+		},
+	},
+}
+
+// Verify regenerates the outputs of v, and returns error on any mismatch.
+func (v *Vector) Verify() error {
+	trx := v.Build()
+	if got := trx.SigningHash(); got != v.SigningHash {
+		return fmt.Errorf("%s: signing hash mismatch: got %v, want %v", v.Name, got, v.SigningHash)
+	}
+
+	priv, err := crypto.HexToECDSA(v.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	signed, err := trx.SignWith(tx.NewPrivateKeySigner(priv))
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	if got := hexutil.Encode(signed.Signature()); got != v.Signature {
+		return fmt.Errorf("%s: signature mismatch: got %v, want %v", v.Name, got, v.Signature)
+	}
+	origin, err := signed.Origin()
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	if origin != v.Origin {
+		return fmt.Errorf("%s: origin mismatch: got %v, want %v", v.Name, origin, v.Origin)
+	}
+	if got := signed.ID(); got != v.ID {
+		return fmt.Errorf("%s: id mismatch: got %v, want %v", v.Name, got, v.ID)
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	if got := hexutil.Encode(raw); got != v.Raw {
+		return fmt.Errorf("%s: raw mismatch: got %v, want %v", v.Name, got, v.Raw)
+	}
+	decoded, err := tx.Decode(v.Raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	reencoded, err := decoded.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.Name, err)
+	}
+	if !bytes.Equal(reencoded, raw) {
+		return fmt.Errorf("%s: decoded tx re-encodes differently", v.Name)
+	}
+	return nil
+}
+
+// VerifyAll verifies all vectors, and returns the first error.
+func VerifyAll() error {
+	for _, v := range Vectors {
+		if err := v.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package testvectors
+
+import "testing"
+
+func TestVectors(t *testing.T) {
+	for _, v := range Vectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			if err := v.Verify(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestVerifyAll(t *testing.T) {
+	if err := VerifyAll(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -7,8 +7,12 @@ package tx
 
 import (
 	"encoding/binary"
+	"encoding/json"
+	"errors"
 
 	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // BlockRef is block reference.
@@ -19,6 +23,33 @@ func (br BlockRef) Number() uint32 {
 	return binary.BigEndian.Uint32(br[:])
 }
 
+// String implements the stringer interface.
+func (br BlockRef) String() string {
+	return hexutil.Encode(br[:])
+}
+
+// MarshalJSON implements json.Marshaler.
+func (br BlockRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(br.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (br *BlockRef) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(br) {
+		return errors.New("invalid block ref length")
+	}
+	copy(br[:], b)
+	return nil
+}
+
 // NewBlockRef create block reference with block number.
 func NewBlockRef(blockNum uint32) (br BlockRef) {
 	binary.BigEndian.PutUint32(br[:], blockNum)
@@ -26,6 +57,8 @@ func NewBlockRef(blockNum uint32) (br BlockRef) {
 }
 
 // NewBlockRefFromID create block reference from block id.
+// The first 8 bytes of block id are copied, which contain the block number
+// and part of the block hash.
 func NewBlockRefFromID(blockID meter.Bytes32) (br BlockRef) {
 	copy(br[:], blockID[:])
 	return
@@ -6,14 +6,28 @@
 package tx
 
 import (
+	"crypto/rand"
 	"encoding/binary"
+	"time"
 
 	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Builder to make it easy to build transaction.
+// A builder can be reused after Build, but it's not safe for concurrent use.
 type Builder struct {
-	body body
+	body     body
+	nonceSet bool
+	params   ChainParams
+	strict   bool // see Strict
+}
+
+// ChainParams set chain tag from params, and the block interval used by ExpireAfter.
+func (b *Builder) ChainParams(params ChainParams) *Builder {
+	b.params = params
+	return b.ChainTag(params.ChainTag)
 }
 
 // ChainTag set chain tag.
@@ -28,6 +42,14 @@ func (b *Builder) Clause(c *Clause) *Builder {
 	return b
 }
 
+// Clauses add clauses in order.
+// Clauses of a tx are executed in order and atomically, if any clause fails,
+// effects of all clauses are reverted.
+func (b *Builder) Clauses(cs ...*Clause) *Builder {
+	b.body.Clauses = append(b.body.Clauses, cs...)
+	return b
+}
+
 // GasPriceCoef set gas price coef.
 func (b *Builder) GasPriceCoef(coef uint8) *Builder {
 	b.body.GasPriceCoef = coef
@@ -52,12 +74,31 @@ func (b *Builder) Expiration(exp uint32) *Builder {
 	return b
 }
 
+// ExpireAfter set expiration to cover the given duration, with the block interval set by
+// a prior call of ChainParams, or DefaultBlockInterval.
+func (b *Builder) ExpireAfter(d time.Duration) *Builder {
+	return b.Expiration(ExpirationForDuration(d, b.params))
+}
+
 // Nonce set nonce.
+// On Meter the nonce is free-form rather than sequential per account, it only
+// makes the tx unique together with blockRef and expiration.
+// If not set, Build fills a random one.
 func (b *Builder) Nonce(nonce uint64) *Builder {
 	b.body.Nonce = nonce
+	b.nonceSet = true
 	return b
 }
 
+// RandomNonce returns a random nonce read from crypto/rand.
+func RandomNonce() (uint64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
 // DependsOn set depended tx.
 func (b *Builder) DependsOn(txID *meter.Bytes32) *Builder {
 	if txID == nil {
@@ -69,8 +110,60 @@ func (b *Builder) DependsOn(txID *meter.Bytes32) *Builder {
 	return b
 }
 
+// Features set features.
+func (b *Builder) Features(feat Features) *Builder {
+	b.body.Reserved.Features = feat
+	return b
+}
+
+// ToBuilder returns a builder seeded with all fields of tx except signature,
+// so that a variant can be built, e.g. with more gas for replacement.
+func (t *Transaction) ToBuilder() *Builder {
+	b := &Builder{body: t.body, nonceSet: true}
+	b.body.Clauses = append([]*Clause(nil), t.body.Clauses...)
+	b.body.Reserved.Unused = append([]rlp.RawValue(nil), t.body.Reserved.Unused...)
+	b.body.DependsOn = t.DependsOn()
+	b.body.Signature = nil
+	return b
+}
+
 // Build build tx object.
+// The built tx is fully independent of the builder, clauses included, so the builder can be
+// modified and reused to build more txs afterwards.
+// It panics if nonce is not set and a random one can't be generated.
 func (b *Builder) Build() *Transaction {
 	tx := Transaction{body: b.body}
+	tx.body.Clauses = copyClauses(b.body.Clauses)
+	if b.body.DependsOn != nil {
+		cpy := *b.body.DependsOn
+		tx.body.DependsOn = &cpy
+	}
+	tx.body.Reserved.Unused = append([]rlp.RawValue(nil), b.body.Reserved.Unused...)
+	tx.body.Signature = append([]byte(nil), b.body.Signature...)
+	if !b.nonceSet {
+		nonce, err := RandomNonce()
+		if err != nil {
+			panic(err)
+		}
+		tx.body.Nonce = nonce
+	}
 	return &tx
 }
+
+// Reset clears all fields, so the builder starts over as a new one.
+func (b *Builder) Reset() *Builder {
+	*b = Builder{}
+	return b
+}
+
+// copyClauses returns a deep copy of clauses with nil values replaced by zero.
+func copyClauses(clauses []*Clause) []*Clause {
+	copied := make([]*Clause, 0, len(clauses))
+	for _, c := range clauses {
+		if c != nil {
+			c = NewClause(c.body.To).WithToken(c.body.Token).WithValue(c.Value()).WithData(c.body.Data)
+		}
+		copied = append(copied, c)
+	}
+	return copied
+}
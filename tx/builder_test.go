@@ -0,0 +1,93 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+func TestBuildIndependentOfBuilder(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	data := []byte{1, 2, 3}
+	clause := tx.NewClause(&to).WithValue(big.NewInt(1)).WithData(data)
+	dep := meter.MustParseBytes32("0x4f833920baf63d77202d977c28b7d9c6d27224033b3c84ffcfdb9e40404aef76")
+
+	b := new(tx.Builder).
+		ChainTag(1).
+		Clause(clause).
+		Gas(21000).
+		DependsOn(&dep).
+		Nonce(1)
+	trx := b.Build()
+	hash := trx.SigningHash()
+	encoded, err := trx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// mutate everything reachable from the builder
+	data[0] = 0xff
+	dep[0] = 0xff
+	if err := json.Unmarshal([]byte(`{"to":null,"value":"0x2","token":1,"data":"0xdead"}`), clause); err != nil {
+		t.Fatal(err)
+	}
+	b.Clause(tx.NewClause(nil)).Gas(50000).ChainTag(2).Nonce(2)
+
+	if got := trx.SigningHash(); got != hash {
+		t.Fatalf("signing hash changed: got %v, want %v", got, hash)
+	}
+	// bypass cached values by checking the encoding as well
+	reencoded, err := trx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reencoded, encoded) {
+		t.Fatal("tx encoding changed after mutating builder")
+	}
+
+	clauses := trx.Clauses()
+	if len(clauses) != 1 {
+		t.Fatalf("got %d clauses, want 1", len(clauses))
+	}
+	c := clauses[0]
+	if c.To() == nil || *c.To() != to {
+		t.Fatalf("clause to changed: %v", c.To())
+	}
+	if c.Value().Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("clause value changed: %v", c.Value())
+	}
+	if c.Token() != tx.MeterToken {
+		t.Fatalf("clause token changed: %v", c.Token())
+	}
+	if !bytes.Equal(c.Data(), []byte{1, 2, 3}) {
+		t.Fatalf("clause data changed: %x", c.Data())
+	}
+	if got := trx.DependsOn(); got == nil || got[0] == 0xff {
+		t.Fatalf("depends on changed: %v", got)
+	}
+}
+
+func TestBuilderReuse(t *testing.T) {
+	b := new(tx.Builder).ChainTag(1).Gas(21000).Nonce(1)
+	first := b.Build()
+	second := b.Gas(42000).Build()
+
+	if first.Gas() != 21000 {
+		t.Fatalf("first tx gas changed: %d", first.Gas())
+	}
+	if second.Gas() != 42000 {
+		t.Fatalf("second tx gas: got %d, want 42000", second.Gas())
+	}
+	if first.SigningHash() == second.SigningHash() {
+		t.Fatal("txs built with different gas share signing hash")
+	}
+}
@@ -6,12 +6,17 @@
 package tx
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 
 	"meter-go/meter"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -45,26 +50,49 @@ func NewClause(to *meter.Address) *Clause {
 }
 
 // WithValue create a new clause copy with value changed.
+// A nil value is treated as zero, and a negative value leaves the clause unchanged,
+// use WithValueChecked to get the error.
 func (c *Clause) WithValue(value *big.Int) *Clause {
+	newClause, err := c.WithValueChecked(value)
+	if err != nil {
+		return c
+	}
+	return newClause
+}
+
+// WithValueChecked create a new clause copy with value changed.
+// A nil value is treated as zero, and a negative value is rejected.
+func (c *Clause) WithValueChecked(value *big.Int) (*Clause, error) {
+	if value != nil && value.Sign() < 0 {
+		return nil, errors.New("negative clause value")
+	}
 	newClause := *c
-	newClause.body.Value = new(big.Int).Set(value)
-	return &newClause
+	newClause.body.Value = new(big.Int)
+	if value != nil {
+		newClause.body.Value.Set(value)
+	}
+	return &newClause, nil
 }
 
-// WithData create a new clause copy with data changed.
+// WithData create a new clause copy with data changed, data is copied.
 func (c *Clause) WithData(data []byte) *Clause {
 	newClause := *c
 	newClause.body.Data = append([]byte(nil), data...)
 	return &newClause
 }
 
-// WithToken create a new clause copy with value changed.
+// WithToken create a new clause copy with token changed.
 func (c *Clause) WithToken(token byte) *Clause {
 	newClause := *c
 	newClause.body.Token = token
 	return &newClause
 }
 
+// WithTokenType create a new clause copy with token changed, e.g. to MeterGovToken.
+func (c *Clause) WithTokenType(token TokenType) *Clause {
+	return c.WithToken(byte(token))
+}
+
 // To returns 'To' address.
 func (c *Clause) To() *meter.Address {
 	if c.body.To == nil {
@@ -74,8 +102,11 @@ func (c *Clause) To() *meter.Address {
 	return &cpy
 }
 
-// Value returns 'Value'.
+// Value returns 'Value', which is never nil.
 func (c *Clause) Value() *big.Int {
+	if c.body.Value == nil {
+		return new(big.Int)
+	}
 	return new(big.Int).Set(c.body.Value)
 }
 
@@ -84,9 +115,70 @@ func (c *Clause) Data() []byte {
 	return append([]byte(nil), c.body.Data...)
 }
 
-// Data returns 'Token'.
-func (c *Clause) Token() byte {
-	return c.body.Token
+// Token returns 'Token'.
+func (c *Clause) Token() TokenType {
+	return TokenType(c.body.Token)
+}
+
+// IsCreatingContract return if this clause is going to create a contract.
+func (c *Clause) IsCreatingContract() bool {
+	return c.body.To == nil
+}
+
+// IntrinsicGas returns the intrinsic gas of the clause, which is the clause gas plus data gas,
+// excluding the base gas of tx.
+func (c *Clause) IntrinsicGas() (uint64, error) {
+	gas, err := dataGas(c.body.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	var cgas uint64
+	if c.IsCreatingContract() {
+		// contract creation
+		cgas = clauseGasContractCreation
+	} else {
+		cgas = clauseGas
+	}
+
+	total, overflow := math.SafeAdd(gas, cgas)
+	if overflow {
+		return 0, errIntrinsicGasOverflow
+	}
+	return total, nil
+}
+
+// ValidToken returns whether the token is MeterToken or MeterGovToken.
+func (c *Clause) ValidToken() bool {
+	return TokenType(c.body.Token).IsValid()
+}
+
+// Equal returns whether two clauses are identical.
+// A nil 'To' (contract creation) is different from a zero address.
+func (c *Clause) Equal(o *Clause) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	if (c.body.To == nil) != (o.body.To == nil) {
+		return false
+	}
+	if c.body.To != nil && *c.body.To != *o.body.To {
+		return false
+	}
+	return bigEqual(c.body.Value, o.body.Value) &&
+		c.body.Token == o.body.Token &&
+		bytes.Equal(c.body.Data, o.body.Data)
+}
+
+// bigEqual compares big.Int by value, treating nil as zero.
+func bigEqual(a, b *big.Int) bool {
+	if a == nil {
+		a = new(big.Int)
+	}
+	if b == nil {
+		b = new(big.Int)
+	}
+	return a.Cmp(b) == 0
 }
 
 // EncodeRLP implements rlp.Encoder
@@ -95,15 +187,62 @@ func (c *Clause) EncodeRLP(w io.Writer) error {
 }
 
 // DecodeRLP implements rlp.Decoder
+// Values beyond 256 bits are rejected, as the node can't handle them.
 func (c *Clause) DecodeRLP(s *rlp.Stream) error {
 	var body clauseBody
 	if err := s.Decode(&body); err != nil {
 		return err
 	}
+	if body.Value == nil {
+		body.Value = new(big.Int)
+	} else if body.Value.BitLen() > 256 {
+		return errors.New("rlp: clause value exceeds 256 bits")
+	}
 	*c = Clause{body}
 	return nil
 }
 
+// clauseJSON is the json form of clause used by the node's api.
+type clauseJSON struct {
+	To    *meter.Address        `json:"to"`
+	Value *math.HexOrDecimal256 `json:"value"`
+	Token byte                  `json:"token"`
+	Data  hexutil.Bytes         `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler, in the form {to, value, token, data} the node expects,
+// where value is a hex quantity, and to is null for contract creation.
+func (c *Clause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&clauseJSON{
+		To:    c.body.To,
+		Value: (*math.HexOrDecimal256)(c.Value()),
+		Token: c.body.Token,
+		Data:  c.body.Data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Value can be hex or decimal, and defaults to 0.
+func (c *Clause) UnmarshalJSON(data []byte) error {
+	var cj clauseJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+	value := new(big.Int)
+	if cj.Value != nil {
+		value = (*big.Int)(cj.Value)
+	}
+	if value.Sign() < 0 {
+		return errors.New("negative clause value")
+	}
+	*c = Clause{clauseBody{
+		To:    cj.To,
+		Value: value,
+		Token: cj.Token,
+		Data:  cj.Data,
+	}}
+	return nil
+}
+
 func (c *Clause) String() string {
 	var to string
 	if c.body.To == nil {
@@ -114,3 +253,17 @@ func (c *Clause) String() string {
 	return fmt.Sprintf(`
     (To: %v, Value: %v, Token: %v, Data: 0x%x)`, to, c.body.Value, c.body.Token, c.body.Data)
 }
+
+// Format implements fmt.Formatter. %v gives a single-line summary, while %+v and %s give the
+// full detail of String.
+func (c *Clause) Format(f fmt.State, verb rune) {
+	if verb == 's' || (verb == 'v' && f.Flag('+')) {
+		io.WriteString(f, c.String())
+		return
+	}
+	to := "nil"
+	if c.body.To != nil {
+		to = c.body.To.String()
+	}
+	fmt.Fprintf(f, "Clause(to: %v, value: %v, token: %v, data: %d bytes)", to, c.body.Value, c.body.Token, len(c.body.Data))
+}
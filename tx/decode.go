@@ -0,0 +1,43 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// errTrailingData is returned when input has bytes after the encoded tx.
+var errTrailingData = errors.New("rlp: trailing data after tx")
+
+// Decode decodes a tx from the 0x-prefixed hex of its RLP encoding.
+func Decode(raw string) (*Transaction, error) {
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBytes(data)
+}
+
+// DecodeBytes decodes a tx from its RLP encoding.
+// The whole input must be consumed, trailing bytes are rejected.
+func DecodeBytes(data []byte) (*Transaction, error) {
+	r := bytes.NewReader(data)
+	s := rlp.NewStream(r, uint64(len(data)))
+
+	var t Transaction
+	if err := s.Decode(&t); err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes", errTrailingData, r.Len())
+	}
+	return &t, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"math"
+	"time"
+
+	"meter-go/meter"
+)
+
+// DefaultBlockInterval is the approximate block interval of Meter networks.
+const DefaultBlockInterval = 2 * time.Second
+
+// ChainParams is the per-chain assumptions used to build txs.
+type ChainParams struct {
+	BlockInterval time.Duration
+	ChainTag      byte
+	GenesisID     meter.Bytes32
+}
+
+// blockInterval returns the block interval, DefaultBlockInterval if not positive.
+func (p ChainParams) blockInterval() time.Duration {
+	if p.BlockInterval <= 0 {
+		return DefaultBlockInterval
+	}
+	return p.BlockInterval
+}
+
+// ExpirationForDuration converts a validity window into expiration in unit block of the chain.
+// The result is rounded up, and clamped to max uint32.
+// DefaultBlockInterval is used if the block interval of params is not positive.
+func ExpirationForDuration(d time.Duration, params ChainParams) uint32 {
+	if d <= 0 {
+		return 0
+	}
+	blockInterval := params.blockInterval()
+	blocks := d / blockInterval
+	if d%blockInterval != 0 {
+		blocks++
+	}
+	if int64(blocks) > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(blocks)
+}
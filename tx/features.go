@@ -0,0 +1,28 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+// Features bitset contains tx features.
+type Features uint32
+
+const (
+	// DelegationFeature marks a tx whose gas is paid by a delegator (VIP-191).
+	DelegationFeature Features = 1
+)
+
+// IsDelegated returns whether the delegation feature is set.
+func (f Features) IsDelegated() bool {
+	return (f & DelegationFeature) == DelegationFeature
+}
+
+// SetDelegated set delegation feature.
+func (f *Features) SetDelegated(flag bool) {
+	if flag {
+		*f |= DelegationFeature
+	} else {
+		*f &= ^DelegationFeature
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"fmt"
+	"strings"
+
+	"meter-go/meter"
+)
+
+// LintIssue is a suspicious clause found by BuildChecked.
+type LintIssue struct {
+	ClauseIndex int
+	Message     string
+	Fatal       bool // the node would reject the tx, regardless of strict mode
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("clause %d: %s", i.ClauseIndex, i.Message)
+}
+
+// LintError is returned by BuildChecked with the issues failing the build.
+type LintError struct {
+	Issues []LintIssue
+}
+
+func (e *LintError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, i := range e.Issues {
+		msgs = append(msgs, i.String())
+	}
+	return "tx lint failed: " + strings.Join(msgs, "; ")
+}
+
+// Strict set whether BuildChecked fails on warnings too.
+func (b *Builder) Strict(strict bool) *Builder {
+	b.strict = strict
+	return b
+}
+
+// BuildChecked builds the tx like Build, after checking clauses for likely mistakes:
+//   - unknown token, which is fatal
+//   - contract creation with non-MTR token, as the value can only be MTR
+//   - clause with neither value nor data, which does nothing
+//
+// Non-fatal issues are returned as warnings, unless in strict mode, where they fail the build
+// as fatal ones do, with a *LintError.
+// A tx exceeding MaxTxSize once signed fails the build with ErrTxTooLarge, so too many clauses
+// are caught before broadcasting.
+func (b *Builder) BuildChecked() (*Transaction, []LintIssue, error) {
+	var (
+		warnings []LintIssue
+		failed   []LintIssue
+	)
+	report := func(issue LintIssue) {
+		if issue.Fatal || b.strict {
+			failed = append(failed, issue)
+		} else {
+			warnings = append(warnings, issue)
+		}
+	}
+	for i, c := range b.body.Clauses {
+		if c == nil {
+			report(LintIssue{i, "nil clause", true})
+			continue
+		}
+		if !c.ValidToken() {
+			report(LintIssue{i, fmt.Sprintf("unknown token %d", c.body.Token), true})
+		}
+		if c.IsCreatingContract() && c.Token() != MeterToken {
+			report(LintIssue{i, fmt.Sprintf("contract creation with token %d", c.body.Token), false})
+		}
+		if c.Value().Sign() == 0 && len(c.body.Data) == 0 {
+			report(LintIssue{i, "neither value nor data", false})
+		}
+	}
+	if len(failed) > 0 {
+		return nil, warnings, &LintError{failed}
+	}
+	t := b.Build()
+	size, err := t.SizeChecked()
+	if err != nil {
+		return nil, warnings, err
+	}
+	if size += unsignedRoom(t); size > MaxTxSize {
+		return nil, warnings, fmt.Errorf("%w: %v once signed, limit %v", ErrTxTooLarge, size, MaxTxSize)
+	}
+	return t, warnings, nil
+}
+
+// unsignedRoom returns the size the missing signatures of t would add.
+// A signature is encoded as a 65 bytes string with a 2 bytes header, in place of the empty
+// string, and the list header of the tx grows by at most 2 bytes.
+func unsignedRoom(t *Transaction) meter.StorageSize {
+	want := signatureLength
+	if t.Features().IsDelegated() {
+		want *= 2
+	}
+	missing := want - len(t.body.Signature)
+	if missing <= 0 {
+		return 0
+	}
+	return meter.StorageSize(missing + 2 + 2)
+}
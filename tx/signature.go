@@ -0,0 +1,96 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrMalleableSignature is returned for signatures whose S is in the upper half of the curve order,
+	// or whose V is not 0/1. Such signatures recover the same signer as their canonical form,
+	// see CanonicalizeSignature.
+	ErrMalleableSignature = errors.New("malleable signature")
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// SignatureLengthError is returned for signatures of unexpected length.
+type SignatureLengthError struct {
+	Expected int
+	Actual   int
+}
+
+func (e *SignatureLengthError) Error() string {
+	return fmt.Sprintf("invalid signature length %d, expected %d", e.Actual, e.Expected)
+}
+
+// SplitDelegatedSignature splits the signature of a delegated tx into
+// the origin part and the delegator part.
+func SplitDelegatedSignature(sig []byte) (origin, delegator []byte, err error) {
+	if len(sig) != signatureLength*2 {
+		return nil, nil, &SignatureLengthError{signatureLength * 2, len(sig)}
+	}
+	origin = append([]byte(nil), sig[:signatureLength]...)
+	delegator = append([]byte(nil), sig[signatureLength:]...)
+	return
+}
+
+// CombineDelegatedSignature concatenates the origin and delegator signatures into
+// the signature of a delegated tx. Lengths are checked by Transaction.WithSignatureChecked.
+func CombineDelegatedSignature(origin, delegator []byte) []byte {
+	sig := make([]byte, 0, len(origin)+len(delegator))
+	sig = append(sig, origin...)
+	return append(sig, delegator...)
+}
+
+// ValidateSignatureValues checks a 65 bytes [R || S || V] signature is canonical,
+// i.e. R and S in range, S in the lower half of the curve order and V is 0/1.
+// It returns ErrMalleableSignature for a non-canonical one.
+func ValidateSignatureValues(sig []byte) error {
+	if len(sig) != signatureLength {
+		return &SignatureLengthError{signatureLength, len(sig)}
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(secp256k1N) >= 0 || s.Cmp(secp256k1N) >= 0 {
+		return errors.New("invalid signature values")
+	}
+	if s.Cmp(secp256k1HalfN) > 0 || sig[64] > 1 {
+		return ErrMalleableSignature
+	}
+	return nil
+}
+
+// CanonicalizeSignature returns the canonical form of a 65 bytes [R || S || V] signature,
+// which recovers the same signer, with S flipped to the lower half and V normalized to 0/1.
+func CanonicalizeSignature(sig []byte) ([]byte, error) {
+	if len(sig) != signatureLength {
+		return nil, &SignatureLengthError{signatureLength, len(sig)}
+	}
+	cpy := append([]byte(nil), sig...)
+	if cpy[64] >= 27 {
+		cpy[64] -= 27
+	}
+	if cpy[64] > 1 {
+		return nil, errors.New("invalid signature recovery id")
+	}
+	s := new(big.Int).SetBytes(cpy[32:64])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(secp256k1N, s)
+		s.FillBytes(cpy[32:64])
+		cpy[64] ^= 1
+	}
+	if err := ValidateSignatureValues(cpy); err != nil {
+		return nil, err
+	}
+	return cpy, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"crypto/ecdsa"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs hashes, which can be backed by a hardware wallet, HSM or remote KMS.
+// Sign must return a 65 bytes recoverable signature in [R || S || V] format, where V is 0 or 1.
+type Signer interface {
+	Sign(hash meter.Bytes32) ([]byte, error)
+}
+
+// PrivateKeySigner signs with an in-memory private key.
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner create a signer with private key.
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key}
+}
+
+// Sign implements Signer.
+func (s *PrivateKeySigner) Sign(hash meter.Bytes32) ([]byte, error) {
+	return crypto.Sign(hash[:], s.key)
+}
+
+// SignWith signs the tx's signing hash with s, and returns a new tx with signature set.
+func (t *Transaction) SignWith(s Signer) (*Transaction, error) {
+	hash, err := t.SigningHashChecked()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != signatureLength {
+		return nil, &SignatureLengthError{signatureLength, len(sig)}
+	}
+	return t.WithSignature(sig), nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+	"sort"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ByID implements sort.Interface to sort txs by ID in ascending byte order.
+// Unsigned txs, whose ID is zero, come first.
+type ByID []*Transaction
+
+func (s ByID) Len() int      { return len(s) }
+func (s ByID) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ByID) Less(i, j int) bool {
+	a, b := s[i].ID(), s[j].ID()
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+// SortTransactions sorts txs by ID, see ByID.
+func SortTransactions(txs []*Transaction) {
+	sort.Stable(ByID(txs))
+}
+
+// ClausesHash returns blake2b of the RLP encoded clause list.
+// It returns zero Bytes32 if the clauses can't be encoded.
+func (t *Transaction) ClausesHash() (hash meter.Bytes32) {
+	data, err := rlp.EncodeToBytes(t.body.Clauses)
+	if err != nil {
+		return
+	}
+	return meter.Blake2b(data)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"meter-go/meter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// specNumber is a number in json spec, presented as json number, decimal string or 0x-prefixed hex string.
+type specNumber struct {
+	big.Int
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *specNumber) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	if _, ok := n.SetString(s, base); !ok {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	if n.Sign() < 0 {
+		return fmt.Errorf("negative number %q", s)
+	}
+	return nil
+}
+
+func (n *specNumber) uint64(name string, bits int) (uint64, error) {
+	if n.BitLen() > bits {
+		return 0, fmt.Errorf("%v exceeds %d bits", name, bits)
+	}
+	return n.Uint64(), nil
+}
+
+type clauseSpec struct {
+	To    *meter.Address `json:"to"`
+	Value *specNumber    `json:"value"`
+	Token *specNumber    `json:"token"`
+	Data  hexutil.Bytes  `json:"data"`
+}
+
+type txSpec struct {
+	ChainTag     *specNumber    `json:"chainTag"`
+	BlockRef     *BlockRef      `json:"blockRef"`
+	Expiration   *specNumber    `json:"expiration"`
+	Clauses      []clauseSpec   `json:"clauses"`
+	GasPriceCoef *specNumber    `json:"gasPriceCoef"`
+	Gas          *specNumber    `json:"gas"`
+	DependsOn    *meter.Bytes32 `json:"dependsOn"`
+	Nonce        *specNumber    `json:"nonce"`
+	Delegated    bool           `json:"delegated"`
+}
+
+// BuildFromJSON builds an unsigned tx from a json spec like:
+//
+//	{
+//	  "chainTag": 88,
+//	  "blockRef": "0x0000a8c00000a8c0",
+//	  "expiration": 32,
+//	  "gasPriceCoef": 128,
+//	  "gas": 21000,
+//	  "nonce": "0x12d687",
+//	  "dependsOn": null,
+//	  "clauses": [{"to": "0x...", "value": "2000000000000000000", "token": 0, "data": "0x"}]
+//	}
+//
+// Numbers can be json numbers, decimal strings or 0x-prefixed hex strings.
+// chainTag, blockRef, expiration and gas are required, a random nonce is used if omitted.
+func BuildFromJSON(spec []byte) (*Transaction, error) {
+	var s txSpec
+	if err := json.Unmarshal(spec, &s); err != nil {
+		return nil, err
+	}
+	if s.ChainTag == nil || s.BlockRef == nil || s.Expiration == nil || s.Gas == nil {
+		return nil, errors.New("chainTag, blockRef, expiration and gas are required")
+	}
+
+	var b Builder
+	chainTag, err := s.ChainTag.uint64("chainTag", 8)
+	if err != nil {
+		return nil, err
+	}
+	expiration, err := s.Expiration.uint64("expiration", 32)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := s.Gas.uint64("gas", 64)
+	if err != nil {
+		return nil, err
+	}
+	b.ChainTag(byte(chainTag)).
+		BlockRef(*s.BlockRef).
+		Expiration(uint32(expiration)).
+		Gas(gas).
+		DependsOn(s.DependsOn)
+
+	if s.GasPriceCoef != nil {
+		coef, err := s.GasPriceCoef.uint64("gasPriceCoef", 8)
+		if err != nil {
+			return nil, err
+		}
+		b.GasPriceCoef(uint8(coef))
+	}
+	if s.Nonce != nil {
+		nonce, err := s.Nonce.uint64("nonce", 64)
+		if err != nil {
+			return nil, err
+		}
+		b.Nonce(nonce)
+	}
+	if s.Delegated {
+		var feat Features
+		feat.SetDelegated(true)
+		b.Features(feat)
+	}
+
+	for i, cs := range s.Clauses {
+		c := NewClause(cs.To).WithData(cs.Data)
+		if cs.Value != nil {
+			c = c.WithValue(&cs.Value.Int)
+		}
+		if cs.Token != nil {
+			token, err := cs.Token.uint64("token", 8)
+			if err != nil {
+				return nil, fmt.Errorf("clause %d: %v", i, err)
+			}
+			c = c.WithToken(byte(token))
+		}
+		b.Clause(c)
+	}
+
+	t := b.Build()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import "fmt"
+
+// SplitClausesByGas greedily packs clauses in order into groups, so that the intrinsic gas of
+// a tx of each group, base tx gas included, is within perTxGasBudget.
+// Only intrinsic gas is counted, so the budget should leave room for the VM gas of the clauses.
+// It returns error if a clause is nil, or a single clause alone exceeds the budget.
+func SplitClausesByGas(clauses []*Clause, perTxGasBudget uint64) ([][]*Clause, error) {
+	var (
+		groups [][]*Clause
+		group  []*Clause
+		used   uint64
+	)
+	for i, c := range clauses {
+		if c == nil {
+			return nil, fmt.Errorf("clause %d: nil clause", i)
+		}
+		gas, err := c.IntrinsicGas()
+		if err != nil {
+			return nil, fmt.Errorf("clause %d: %w", i, err)
+		}
+		if gas > perTxGasBudget || txGas > perTxGasBudget-gas {
+			return nil, fmt.Errorf("clause %d: intrinsic gas %d exceeds budget %d", i, gas+txGas, perTxGasBudget)
+		}
+		if len(group) > 0 && gas > perTxGasBudget-used {
+			groups = append(groups, group)
+			group = nil
+		}
+		if len(group) == 0 {
+			used = txGas
+		}
+		group = append(group, c)
+		used += gas
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
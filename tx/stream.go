@@ -0,0 +1,56 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EncodeTransactions writes txs to w one after another, each in its RLP encoding.
+// An RLP item is prefixed with its length, so the output is decoded by DecodeTransactions
+// without any separator, and txs are encoded straight into w without buffering the batch.
+func EncodeTransactions(w io.Writer, txs []*Transaction) error {
+	bw := bufio.NewWriter(w)
+	for i, t := range txs {
+		if err := rlp.Encode(bw, t); err != nil {
+			return fmt.Errorf("encode tx %d: %w", i, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeTransactions reads txs written by EncodeTransactions from r until EOF.
+// Txs are decoded as they are read, so r can be e.g. a file of any size.
+// Each tx is bounded by MaxTxSize, and a larger one fails with ErrTxTooLarge before it's read.
+func DecodeTransactions(r io.Reader) ([]*Transaction, error) {
+	br := bufio.NewReader(r)
+	s := rlp.NewStream(br, 0)
+
+	var txs []*Transaction
+	for {
+		// the limit is checked against the length prefix, so a crafted one can't force a huge allocation
+		s.Reset(br, uint64(MaxTxSize))
+		if _, _, err := s.Kind(); err != nil {
+			if err == io.EOF {
+				return txs, nil
+			}
+			if errors.Is(err, rlp.ErrValueTooLarge) {
+				err = ErrTxTooLarge
+			}
+			return nil, fmt.Errorf("decode tx %d: %w", len(txs), err)
+		}
+		var t Transaction
+		if err := s.Decode(&t); err != nil {
+			return nil, fmt.Errorf("decode tx %d: %w", len(txs), err)
+		}
+		txs = append(txs, &t)
+	}
+}
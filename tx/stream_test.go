@@ -0,0 +1,89 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx_test
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+
+	"meter-go/meter"
+	"meter-go/tx"
+)
+
+func TestTransactionsRoundTrip(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	txs := []*tx.Transaction{
+		signTestTx(t, newTestTx(tx.NewClause(&to))),
+		newTestTx(tx.NewClause(nil).WithData([]byte{0x60, 0x60})),
+		// the largest tx accepted
+		newTestTx(tx.NewClause(&to).WithData(make([]byte, int(tx.MaxTxSize)-200))),
+	}
+	var buf bytes.Buffer
+	if err := tx.EncodeTransactions(&buf, txs); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := tx.DecodeTransactions(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(txs) {
+		t.Fatalf("decoded %d txs, want %d", len(decoded), len(txs))
+	}
+	for i := range txs {
+		if !decoded[i].Equal(txs[i]) {
+			t.Errorf("tx %d: decoded %v, want %v", i, decoded[i], txs[i])
+		}
+	}
+
+	if decoded, err := tx.DecodeTransactions(bytes.NewReader(nil)); err != nil || len(decoded) != 0 {
+		t.Errorf("empty input: got %v, %v", decoded, err)
+	}
+}
+
+func TestDecodeTransactionsLimit(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	var valid bytes.Buffer
+	if err := tx.EncodeTransactions(&valid, []*tx.Transaction{newTestTx(tx.NewClause(&to))}); err != nil {
+		t.Fatal(err)
+	}
+	var large bytes.Buffer
+	if err := tx.EncodeTransactions(&large, []*tx.Transaction{
+		newTestTx(tx.NewClause(&to).WithData(make([]byte, int(tx.MaxTxSize)))),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		// list prefixes claiming 4GiB and 2^64-1 bytes, with nothing behind them
+		{"4GiB list", []byte{0xfb, 0xff, 0xff, 0xff, 0xff}},
+		{"max list", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"oversized string", []byte{0xbb, 0x7f, 0xff, 0xff, 0xff}},
+		{"after a valid tx", append(valid.Bytes(), 0xfb, 0xff, 0xff, 0xff, 0xff)},
+		{"real large tx", large.Bytes()},
+	}
+	for _, tt := range tests {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		decoded, err := tx.DecodeTransactions(bytes.NewReader(tt.data))
+		runtime.ReadMemStats(&after)
+		if !errors.Is(err, tx.ErrTxTooLarge) {
+			t.Errorf("%s: got %v, %v, want ErrTxTooLarge", tt.name, decoded, err)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > uint64(tx.MaxTxSize)*4 {
+			t.Errorf("%s: allocated %d bytes", tt.name, alloc)
+		}
+	}
+
+	// truncated input is not mistaken for a clean end
+	if _, err := tx.DecodeTransactions(bytes.NewReader(valid.Bytes()[:valid.Len()-1])); err == nil {
+		t.Error("expected error for truncated tx")
+	}
+}
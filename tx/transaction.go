@@ -6,11 +6,13 @@
 package tx
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"sync/atomic"
 
 	"meter-go/meter"
 
@@ -26,13 +28,43 @@ const (
 	MeterGovToken = TokenType(1)
 )
 
+// IsValid returns whether the token type is known.
+func (tt TokenType) IsValid() bool {
+	return tt == MeterToken || tt == MeterGovToken
+}
+
+// MaxTxSize is the max size of a RLP encoded tx accepted by the node's tx pool.
+const MaxTxSize = 64 * meter.KiB
+
+const (
+	// signatureLength is the length of a single recoverable signature.
+	signatureLength = 65
+
+	txGas                     = uint64(5000)
+	clauseGas                 = uint64(21000) - txGas
+	clauseGasContractCreation = uint64(53000) - txGas
+	txDataZeroGas             = uint64(4)
+	txDataNonZeroGas          = uint64(68)
+)
+
 var (
 	errIntrinsicGasOverflow = errors.New("intrinsic gas overflow")
+
+	// ErrTxTooLarge is returned by BuildChecked for a tx larger than MaxTxSize once signed,
+	// and by DecodeTransactions for an encoded tx larger than MaxTxSize.
+	ErrTxTooLarge = errors.New("tx too large")
 )
 
 // Transaction is an immutable tx type.
 type Transaction struct {
 	body body
+
+	cache struct {
+		signingHash atomic.Value
+		signer      atomic.Value
+		id          atomic.Value
+		size        atomic.Value
+	}
 }
 
 // body describes details of a tx.
@@ -45,10 +77,73 @@ type body struct {
 	Gas          uint64
 	DependsOn    *meter.Bytes32 `rlp:"nil"`
 	Nonce        uint64
-	Reserved     []interface{}
+	Reserved     reserved
 	Signature    []byte
 }
 
+// reserved describes the reserved fields of a tx.
+// Unused holds entries not yet known to this package, kept for forward compatibility.
+// Decoded entries are kept as-is, even if not trimmed, so that a decoded tx re-encodes byte-identically.
+type reserved struct {
+	Features Features
+	Unused   []rlp.RawValue
+
+	// emptyFeatures is set when a lone empty features entry is decoded, which would be trimmed otherwise.
+	emptyFeatures bool
+}
+
+// EncodeRLP implements rlp.Encoder.
+// Txs without features and unused entries keep an empty list.
+func (r *reserved) EncodeRLP(w io.Writer) error {
+	var raws []rlp.RawValue
+	if r.Features != 0 || len(r.Unused) > 0 || r.emptyFeatures {
+		raw, err := rlp.EncodeToBytes(r.Features)
+		if err != nil {
+			return err
+		}
+		raws = append(raws, raw)
+	}
+	raws = append(raws, r.Unused...)
+	return rlp.Encode(w, raws)
+}
+
+// DecodeRLP implements rlp.Decoder.
+// Entries not trimmed are tolerated, and rejected by Transaction.Validate instead, as the node does.
+func (r *reserved) DecodeRLP(s *rlp.Stream) error {
+	var raws []rlp.RawValue
+	if err := s.Decode(&raws); err != nil {
+		return err
+	}
+	if len(raws) == 0 {
+		*r = reserved{}
+		return nil
+	}
+
+	var feat Features
+	if err := rlp.DecodeBytes(raws[0], &feat); err != nil {
+		return err
+	}
+	*r = reserved{
+		Features:      feat,
+		Unused:        raws[1:],
+		emptyFeatures: feat == 0 && len(raws) == 1,
+	}
+	return nil
+}
+
+// trimmed returns whether the encoded entries has no trailing empty entry.
+func (r *reserved) trimmed() bool {
+	if len(r.Unused) > 0 {
+		return !isEmptyRaw(r.Unused[len(r.Unused)-1])
+	}
+	return !r.emptyFeatures
+}
+
+// isEmptyRaw returns whether the raw value is an empty string or an empty list.
+func isEmptyRaw(raw rlp.RawValue) bool {
+	return len(raw) == 1 && (raw[0] == rlp.EmptyString[0] || raw[0] == rlp.EmptyList[0])
+}
+
 // ChainTag returns chain tag.
 func (t *Transaction) ChainTag() byte {
 	return t.body.ChainTag
@@ -77,25 +172,97 @@ func (t *Transaction) IsExpired(blockNum uint32) bool {
 	return uint64(blockNum) > uint64(t.BlockRef().Number())+uint64(t.body.Expiration) // cast to uint64 to prevent potential overflow
 }
 
+// BlockRefAge returns how many blocks the blockRef is behind bestNum, 0 if it's ahead.
+// An aged blockRef shortens the remaining valid window of the tx, so a tx whose age approaches
+// its expiration should be rebuilt with a fresh blockRef before broadcasting.
+func (t *Transaction) BlockRefAge(bestNum uint32) uint32 {
+	refNum := t.BlockRef().Number()
+	if bestNum <= refNum {
+		return 0
+	}
+	return bestNum - refNum
+}
+
+// BlockNumberer is implemented by block types carrying a block number.
+type BlockNumberer interface {
+	BlockNumber() uint32
+}
+
+// IsExpiredAt returns whether the tx is expired according to the given block.
+func (t *Transaction) IsExpiredAt(b BlockNumberer) bool {
+	return t.IsExpired(b.BlockNumber())
+}
+
+// ExpiresAtBlock returns the last block number the tx can be included in,
+// which is blockRef.Number + expiration, clamped to max uint32.
+func (t *Transaction) ExpiresAtBlock() uint32 {
+	n := uint64(t.BlockRef().Number()) + uint64(t.body.Expiration)
+	if n > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(n)
+}
+
 // ID returns id of tx.
 // ID = hash(signingHash, signer).
 // It returns zero Bytes32 if signer not available.
 func (t *Transaction) ID() (id meter.Bytes32) {
+	if cached := t.cache.id.Load(); cached != nil {
+		return cached.(meter.Bytes32)
+	}
 	signer, err := t.Signer()
 	if err != nil {
 		return
 	}
-	hw := meter.NewBlake2b()
-	hw.Write(t.SigningHash().Bytes())
-	hw.Write(signer.Bytes())
-	hw.Sum(id[:0])
+	signingHash, err := t.SigningHashChecked()
+	if err != nil {
+		return
+	}
+	id = meter.Blake2b(signingHash.Bytes(), signer.Bytes())
+	t.cache.id.Store(id)
 	return
 }
 
+// UnsignedID returns an identifier of tx available before signing, which is the signing hash.
+// It's stable through signing, but differs from ID, the canonical id of a signed tx.
+func (t *Transaction) UnsignedID() meter.Bytes32 {
+	return t.SigningHash()
+}
+
+// Fingerprint returns hash of the full encoded tx, including the signature.
+// Unlike the others, it distinguishes txs that differ only in signature:
+//   - SigningHash (and UnsignedID) covers the body excluding the signature
+//   - ID is hash(signingHash, signer), so it's the same for any valid signature of the same signer
+//   - Fingerprint covers every encoded byte, signature included
+//
+// It returns zero Bytes32 if the tx can't be encoded.
+func (t *Transaction) Fingerprint() (fp meter.Bytes32) {
+	data, err := rlp.EncodeToBytes(t)
+	if err != nil {
+		return
+	}
+	return meter.Blake2b(data)
+}
+
 // SigningHash returns hash of tx excludes signature.
-func (t *Transaction) SigningHash() (hash meter.Bytes32) {
+// It panics if the tx body can't be encoded, rather than returning a zero hash
+// which might be signed by accident. Use SigningHashChecked to get the error.
+func (t *Transaction) SigningHash() meter.Bytes32 {
+	hash, err := t.SigningHashChecked()
+	if err != nil {
+		panic(fmt.Sprintf("tx: cannot compute signing hash: %v", err))
+	}
+	return hash
+}
+
+// SigningHashChecked returns hash of tx excludes signature, or error if the tx body can't be encoded.
+// The hash is cached, as the body is immutable.
+func (t *Transaction) SigningHashChecked() (hash meter.Bytes32, err error) {
+	if cached := t.cache.signingHash.Load(); cached != nil {
+		return cached.(meter.Bytes32), nil
+	}
 	hw := meter.NewBlake2b()
-	err := rlp.Encode(hw, []interface{}{
+	err = rlp.Encode(hw, []interface{}{
 		t.body.ChainTag,
 		t.body.BlockRef,
 		t.body.Expiration,
@@ -104,14 +271,15 @@ func (t *Transaction) SigningHash() (hash meter.Bytes32) {
 		t.body.Gas,
 		t.body.DependsOn,
 		t.body.Nonce,
-		t.body.Reserved,
+		&t.body.Reserved,
 	})
 	if err != nil {
-		return
+		return meter.Bytes32{}, err
 	}
 
 	hw.Sum(hash[:0])
-	return
+	t.cache.signingHash.Store(hash)
+	return hash, nil
 }
 
 // GasPriceCoef returns gas price coef.
@@ -130,6 +298,24 @@ func (t *Transaction) Clauses() []*Clause {
 	return append([]*Clause(nil), t.body.Clauses...)
 }
 
+// ContractAddress returns the address of the contract to be created by the clause at index.
+// The tx must be signed, since the address is derived from tx id.
+func (t *Transaction) ContractAddress(clauseIndex int) (meter.Address, error) {
+	if clauseIndex < 0 || clauseIndex >= len(t.body.Clauses) {
+		return meter.Address{}, fmt.Errorf("clause index %d out of range", clauseIndex)
+	}
+	if !t.body.Clauses[clauseIndex].IsCreatingContract() {
+		return meter.Address{}, fmt.Errorf("clause %d is not creating contract", clauseIndex)
+	}
+	if len(t.body.Signature) == 0 {
+		return meter.Address{}, errors.New("tx not signed")
+	}
+	if _, err := t.Signer(); err != nil {
+		return meter.Address{}, err
+	}
+	return meter.CreateContractAddress(t.ID(), uint32(clauseIndex), 0), nil
+}
+
 // DependsOn returns depended tx hash.
 func (t *Transaction) DependsOn() *meter.Bytes32 {
 	if t.body.DependsOn == nil {
@@ -144,21 +330,146 @@ func (t *Transaction) Signature() []byte {
 	return append([]byte(nil), t.body.Signature...)
 }
 
-// Signer extract signer of tx from signature.
+// Equal returns whether two txs are identical, including signature.
+func (t *Transaction) Equal(o *Transaction) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	a, b := &t.body, &o.body
+	if a.ChainTag != b.ChainTag ||
+		a.BlockRef != b.BlockRef ||
+		a.Expiration != b.Expiration ||
+		a.GasPriceCoef != b.GasPriceCoef ||
+		a.Gas != b.Gas ||
+		a.Nonce != b.Nonce {
+		return false
+	}
+	if (a.DependsOn == nil) != (b.DependsOn == nil) {
+		return false
+	}
+	if a.DependsOn != nil && *a.DependsOn != *b.DependsOn {
+		return false
+	}
+	if len(a.Clauses) != len(b.Clauses) {
+		return false
+	}
+	for i := range a.Clauses {
+		if !a.Clauses[i].Equal(b.Clauses[i]) {
+			return false
+		}
+	}
+	if a.Reserved.Features != b.Reserved.Features ||
+		a.Reserved.emptyFeatures != b.Reserved.emptyFeatures ||
+		len(a.Reserved.Unused) != len(b.Reserved.Unused) {
+		return false
+	}
+	for i := range a.Reserved.Unused {
+		if !bytes.Equal(a.Reserved.Unused[i], b.Reserved.Unused[i]) {
+			return false
+		}
+	}
+	return bytes.Equal(a.Signature, b.Signature)
+}
+
+// Origin returns the origin of tx, who signs the tx and sends the clauses.
+// For a delegated tx, it's the signer of the first signature.
+// It returns zero address if the tx is not signed.
+func (t *Transaction) Origin() (meter.Address, error) {
+	origin, err := t.Signer()
+	if err != nil {
+		return meter.Address{}, fmt.Errorf("cannot recover origin: %w", err)
+	}
+	return origin, nil
+}
+
+// GasPayer returns the account who pays gas for the tx,
+// which is the delegator for a delegated tx, or the origin otherwise.
+func (t *Transaction) GasPayer() (meter.Address, error) {
+	if !t.IsDelegated() {
+		return t.Origin()
+	}
+	delegator, err := t.DelegatorSigner()
+	if err != nil {
+		return meter.Address{}, fmt.Errorf("cannot recover delegator: %w", err)
+	}
+	return *delegator, nil
+}
+
+// Signer extract signer of tx from signature, it's an alias of Origin without error wrapping.
+// The recovered signer is cached, as the signature of a tx never changes.
+// A non-canonical signature results in ErrMalleableSignature.
 func (t *Transaction) Signer() (signer meter.Address, err error) {
 	// set the origin to nil if no signature
 	if len(t.body.Signature) == 0 {
 		return meter.Address{}, nil
 	}
+	if cached := t.cache.signer.Load(); cached != nil {
+		return cached.(meter.Address), nil
+	}
 
-	pub, err := crypto.SigToPub(t.SigningHash().Bytes(), t.body.Signature)
+	sig := t.body.Signature
+	if t.IsDelegated() && len(sig) > signatureLength {
+		// the first part is signed by origin
+		sig = sig[:signatureLength]
+	}
+	if err := ValidateSignatureValues(sig); err != nil {
+		return meter.Address{}, err
+	}
+	signingHash, err := t.SigningHashChecked()
+	if err != nil {
+		return meter.Address{}, err
+	}
+	pub, err := crypto.SigToPub(signingHash.Bytes(), sig)
 	if err != nil {
 		return meter.Address{}, err
 	}
 	signer = meter.Address(crypto.PubkeyToAddress(*pub))
+	t.cache.signer.Store(signer)
 	return
 }
 
+// Features returns features.
+func (t *Transaction) Features() Features {
+	return t.body.Reserved.Features
+}
+
+// IsDelegated returns whether the tx is delegated, which means its gas is paid by a delegator.
+func (t *Transaction) IsDelegated() bool {
+	return t.body.Reserved.Features.IsDelegated()
+}
+
+// DelegatorSigningHash returns hash of tx components for delegator to sign, by integrating tx origin address.
+// delegatorSigningHash = hash(signingHash, origin).
+func (t *Transaction) DelegatorSigningHash(origin meter.Address) meter.Bytes32 {
+	return meter.Blake2b(t.SigningHash().Bytes(), origin.Bytes())
+}
+
+// DelegatorSigner extract delegator of tx from signature.
+// It returns nil if the tx is not delegated.
+func (t *Transaction) DelegatorSigner() (delegator *meter.Address, err error) {
+	if !t.IsDelegated() {
+		return nil, nil
+	}
+	if len(t.body.Signature) != signatureLength*2 {
+		return nil, errors.New("delegator signature not available")
+	}
+
+	origin, err := t.Signer()
+	if err != nil {
+		return nil, err
+	}
+	sig := t.body.Signature[signatureLength:]
+	if err := ValidateSignatureValues(sig); err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(t.DelegatorSigningHash(origin).Bytes(), sig)
+	if err != nil {
+		return nil, err
+	}
+	addr := meter.Address(crypto.PubkeyToAddress(*pub))
+	return &addr, nil
+}
+
 // WithSignature create a new tx with signature set.
 func (t *Transaction) WithSignature(sig []byte) *Transaction {
 	newTx := Transaction{
@@ -166,13 +477,50 @@ func (t *Transaction) WithSignature(sig []byte) *Transaction {
 	}
 	// copy sig
 	newTx.body.Signature = append([]byte(nil), sig...)
+	newTx.inheritSigningHash(t)
 	return &newTx
 }
 
-// HasReservedFields returns if there're reserved fields.
+// WithSignatureChecked create a new tx with signature set, after checking signature length,
+// which must be 65 bytes, or 130 bytes (origin + delegator) for a delegated tx.
+func (t *Transaction) WithSignatureChecked(sig []byte) (*Transaction, error) {
+	expected := signatureLength
+	if t.IsDelegated() {
+		expected = signatureLength * 2
+	}
+	if len(sig) != expected {
+		return nil, &SignatureLengthError{expected, len(sig)}
+	}
+	return t.WithSignature(sig), nil
+}
+
+// WithDelegatorSignature create a new tx with delegator signature appended
+// to the origin signature.
+// For a delegated tx, signature = originSig(65 bytes) + delegatorSig(65 bytes).
+func (t *Transaction) WithDelegatorSignature(sig []byte) *Transaction {
+	newTx := Transaction{
+		body: t.body,
+	}
+	originSig := t.body.Signature
+	if len(originSig) > signatureLength {
+		originSig = originSig[:signatureLength]
+	}
+	newTx.body.Signature = append(append([]byte(nil), originSig...), sig...)
+	newTx.inheritSigningHash(t)
+	return &newTx
+}
+
+// inheritSigningHash carries the cached signing hash of src, which differs from t only in signature.
+func (t *Transaction) inheritSigningHash(src *Transaction) {
+	if cached := src.cache.signingHash.Load(); cached != nil {
+		t.cache.signingHash.Store(cached)
+	}
+}
+
+// HasReservedFields returns if there're reserved fields not recognized by this package.
 // Reserved fields are for backward compatibility purpose.
 func (t *Transaction) HasReservedFields() bool {
-	return len(t.body.Reserved) > 0
+	return len(t.body.Reserved.Unused) > 0
 }
 
 // EncodeRLP implements rlp.Encoder
@@ -181,11 +529,16 @@ func (t *Transaction) EncodeRLP(w io.Writer) error {
 }
 
 // DecodeRLP implements rlp.Decoder
+// As txs come from the network, only the canonical encoding is accepted, so that a decoded tx
+// re-encodes to the same bytes, and malformed input results in error rather than panic.
 func (t *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, _, err := s.Kind()
+	kind, _, err := s.Kind()
 	if err != nil {
 		return err
 	}
+	if kind != rlp.List {
+		return rlp.ErrExpectedList
+	}
 	var body body
 	if err := s.Decode(&body); err != nil {
 		return err
@@ -194,30 +547,169 @@ func (t *Transaction) DecodeRLP(s *rlp.Stream) error {
 	return nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler, using RLP encoding.
+func (t *Transaction) MarshalBinary() ([]byte, error) {
+	return rlp.EncodeToBytes(t)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, using RLP encoding.
+func (t *Transaction) UnmarshalBinary(data []byte) error {
+	decoded, err := DecodeBytes(data)
+	if err != nil {
+		return err
+	}
+	*t = Transaction{body: decoded.body}
+	return nil
+}
+
 // Size returns size in bytes when RLP encoded.
+// It returns 0 if the tx can't be encoded, see SizeChecked.
 func (t *Transaction) Size() meter.StorageSize {
+	size, _ := t.SizeChecked()
+	return size
+}
+
+// ExceedsSizeLimit returns whether the tx is larger than MaxTxSize, so the node would reject it.
+func (t *Transaction) ExceedsSizeLimit() bool {
+	return t.Size() > MaxTxSize
+}
+
+// SizeChecked returns size in bytes when RLP encoded, or error if encoding fails.
+func (t *Transaction) SizeChecked() (meter.StorageSize, error) {
+	if cached := t.cache.size.Load(); cached != nil {
+		return cached.(meter.StorageSize), nil
+	}
 	var size meter.StorageSize
-	err := rlp.Encode(&size, t)
-	if err != nil {
-		fmt.Printf("rlp failed: %s\n", err.Error())
-		return 0
+	if err := rlp.Encode(&size, t); err != nil {
+		return 0, err
 	}
-	return size
+	t.cache.size.Store(size)
+	return size, nil
 }
 
 // GasPrice returns gas price.
 // gasPrice = baseGasPrice + baseGasPrice * gasPriceCoef / 255
 func (t *Transaction) GasPrice(baseGasPrice *big.Int) *big.Int {
-	x := big.NewInt(int64(t.body.GasPriceCoef))
+	return gasPrice(baseGasPrice, t.body.GasPriceCoef)
+}
+
+func gasPrice(baseGasPrice *big.Int, coef uint8) *big.Int {
+	x := big.NewInt(int64(coef))
 	x.Mul(x, baseGasPrice)
 	x.Div(x, big.NewInt(math.MaxUint8))
 	return x.Add(x, baseGasPrice)
 }
 
+// GasPriceCoefFor returns the smallest gas price coef whose gas price reaches target,
+// by inverting gasPrice = baseGasPrice + baseGasPrice * gasPriceCoef / 255.
+// The coef is clamped to 255 if target is above twice of the base, so the resulting
+// gas price may be below target then.
+func GasPriceCoefFor(target, baseGasPrice *big.Int) (uint8, error) {
+	if target == nil || baseGasPrice == nil || baseGasPrice.Sign() <= 0 {
+		return 0, errors.New("invalid gas price")
+	}
+	if target.Cmp(baseGasPrice) < 0 {
+		return 0, errors.New("target gas price below base gas price")
+	}
+	// binary search, as gas price is monotonic with coef
+	lo, hi := 0, math.MaxUint8
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if gasPrice(baseGasPrice, uint8(mid)).Cmp(target) >= 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return uint8(lo), nil
+}
+
+// BumpGasPriceCoef returns an unsigned copy of tx as its replacement, with gas price coef increased
+// by percent, and everything else including nonce preserved.
+// As coef is an integer in [0, 255], the increase is rounded up to at least 1 and clamped to 255,
+// so small coefs bump by much more than percent. Note that coef scales the part of gas price above
+// base gas price, i.e. gasPrice = base * (1 + coef/255), so the gas price itself rises less than percent.
+// It returns error if percent is not positive or coef is already 255.
+func (t *Transaction) BumpGasPriceCoef(percent int) (*Transaction, error) {
+	if percent <= 0 {
+		return nil, errors.New("percent must be positive")
+	}
+	coef := uint64(t.body.GasPriceCoef)
+	if coef == math.MaxUint8 {
+		return nil, errors.New("gas price coef already maximum")
+	}
+	p := uint64(percent)
+	if p > 100*math.MaxUint8 {
+		p = 100 * math.MaxUint8 // enough to reach the maximum, and prevents overflow
+	}
+	inc := (coef*p + 99) / 100
+	if inc == 0 {
+		inc = 1
+	}
+	bumped := coef + inc
+	if bumped > math.MaxUint8 {
+		bumped = math.MaxUint8
+	}
+	return t.ToBuilder().GasPriceCoef(uint8(bumped)).Build(), nil
+}
+
+// Validate checks the tx body, and returns error if reserved fields are not trimmed,
+// or any clause has unknown token.
+func (t *Transaction) Validate() error {
+	if !t.body.Reserved.trimmed() {
+		return errors.New("reserved fields not trimmed")
+	}
+	for i, c := range t.body.Clauses {
+		if !c.ValidToken() {
+			return fmt.Errorf("clause %d: unknown token %v", i, c.body.Token)
+		}
+	}
+	return nil
+}
+
+// IntrinsicGas returns intrinsic gas of tx.
+func (t *Transaction) IntrinsicGas() (uint64, error) {
+	return IntrinsicGas(t.body.Clauses...)
+}
+
+// OutgoingByToken returns clause values summed by token.
+// Contract creation clauses without value are skipped.
+// Unknown token bytes are kept under their own keys, check them with TokenType.IsValid.
+func (t *Transaction) OutgoingByToken() map[TokenType]*big.Int {
+	values := make(map[TokenType]*big.Int)
+	for _, c := range t.body.Clauses {
+		if c.IsCreatingContract() && c.body.Value.Sign() == 0 {
+			continue
+		}
+		token := TokenType(c.body.Token)
+		if _, ok := values[token]; !ok {
+			values[token] = new(big.Int)
+		}
+		values[token].Add(values[token], c.body.Value)
+	}
+	return values
+}
+
+// Cost returns the gas cost and the clause values of the tx.
+// gasCost = gas * gasPrice, paid in MeterToken.
+// Clause values are summed by token, since MeterToken and MeterGovToken are not interchangeable.
+func (t *Transaction) Cost(baseGasPrice *big.Int) (gasCost *big.Int, values map[TokenType]*big.Int, err error) {
+	if baseGasPrice == nil || baseGasPrice.Sign() < 0 {
+		return nil, nil, errors.New("invalid base gas price")
+	}
+	gasCost = new(big.Int).SetUint64(t.body.Gas)
+	gasCost.Mul(gasCost, t.GasPrice(baseGasPrice))
+
+	if err := t.Validate(); err != nil {
+		return nil, nil, err
+	}
+	values = t.OutgoingByToken()
+	return gasCost, values, nil
+}
+
 func (t *Transaction) String() string {
 	var (
 		from      string
-		br        BlockRef
 		dependsOn string
 	)
 	signer, err := t.Signer()
@@ -227,7 +719,7 @@ func (t *Transaction) String() string {
 		from = signer.String()
 	}
 
-	binary.BigEndian.PutUint64(br[:], t.body.BlockRef)
+	br := t.BlockRef()
 	if t.body.DependsOn == nil {
 		dependsOn = "nil"
 	} else {
@@ -237,15 +729,95 @@ func (t *Transaction) String() string {
 	return fmt.Sprintf(`
   Tx(%v, %v)
   From:           %v
-  Clauses:        %v
+  Clauses:        %+v
   GasPriceCoef:   %v
   Gas:            %v
   ChainTag:       %v
-  BlockRef:       %v-%x
+  BlockRef:       %v (%v)
   Expiration:     %v
   DependsOn:      %v
   Nonce:          %v
   Signature:      0x%x
 `, t.ID(), t.Size(), from, t.body.Clauses, t.body.GasPriceCoef, t.body.Gas,
-		t.body.ChainTag, br.Number(), br[4:], t.body.Expiration, dependsOn, t.body.Nonce, t.body.Signature)
+		t.body.ChainTag, br, br.Number(), t.body.Expiration, dependsOn, t.body.Nonce, t.body.Signature)
+}
+
+// Format implements fmt.Formatter. %v gives a single-line summary, while %+v and %s give the
+// full detail of String.
+func (t *Transaction) Format(f fmt.State, verb rune) {
+	if verb == 's' || (verb == 'v' && f.Flag('+')) {
+		io.WriteString(f, t.String())
+		return
+	}
+	from := "N/A"
+	if signer, err := t.Signer(); err == nil {
+		from = signer.String()
+	}
+	fmt.Fprintf(f, "Tx(%v, from: %v, clauses: %d, gas: %d)", t.ID(), from, len(t.body.Clauses), t.body.Gas)
+}
+
+// Describe returns String output extended with the validity window and features.
+func (t *Transaction) Describe() string {
+	var delegator string
+	if t.IsDelegated() {
+		if d, err := t.DelegatorSigner(); err != nil {
+			delegator = "N/A"
+		} else {
+			delegator = d.String()
+		}
+	} else {
+		delegator = "nil"
+	}
+
+	return t.String() + fmt.Sprintf(`  ValidBlocks:    [%v .. %v]
+  Features:       %v (delegated: %v)
+  Delegator:      %v
+  ReservedFields: %v
+`, t.BlockRef().Number(), t.ExpiresAtBlock(), uint32(t.body.Reserved.Features), t.IsDelegated(),
+		delegator, len(t.body.Reserved.Unused))
+}
+
+// IntrinsicGas calculate intrinsic gas cost for tx with such clauses.
+func IntrinsicGas(clauses ...*Clause) (uint64, error) {
+	if len(clauses) == 0 {
+		return txGas + clauseGas, nil
+	}
+
+	var total = txGas
+	var overflow bool
+	for _, c := range clauses {
+		gas, err := c.IntrinsicGas()
+		if err != nil {
+			return 0, err
+		}
+		total, overflow = math.SafeAdd(total, gas)
+		if overflow {
+			return 0, errIntrinsicGasOverflow
+		}
+	}
+	return total, nil
+}
+
+// dataGas calculate gas cost for tx data.
+func dataGas(data []byte) (uint64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	z := uint64(bytes.Count(data, []byte{0}))
+	nz := uint64(len(data)) - z
+
+	zgas, overflow := math.SafeMul(txDataZeroGas, z)
+	if overflow {
+		return 0, errIntrinsicGasOverflow
+	}
+	nzgas, overflow := math.SafeMul(txDataNonZeroGas, nz)
+	if overflow {
+		return 0, errIntrinsicGasOverflow
+	}
+
+	gas, overflow := math.SafeAdd(zgas, nzgas)
+	if overflow {
+		return 0, errIntrinsicGasOverflow
+	}
+	return gas, nil
 }
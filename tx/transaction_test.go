@@ -0,0 +1,305 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"meter-go/client"
+	"meter-go/meter"
+	"meter-go/testvectors"
+	"meter-go/tx"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var testKey, _ = crypto.HexToECDSA(testvectors.Vectors[0].PrivateKey)
+
+func newTestTx(clauses ...*tx.Clause) *tx.Transaction {
+	return new(tx.Builder).
+		ChainTag(1).
+		BlockRef(tx.BlockRef{0, 0, 0, 0, 0xaa, 0xbb, 0xcc, 0xdd}).
+		Expiration(32).
+		Clauses(clauses...).
+		Gas(100000).
+		Nonce(1).
+		Build()
+}
+
+func signTestTx(t testing.TB, trx *tx.Transaction) *tx.Transaction {
+	signed, err := trx.SignWith(tx.NewPrivateKeySigner(testKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func FuzzDecodeTransaction(f *testing.F) {
+	for _, v := range testvectors.Vectors {
+		f.Add(hexutil.MustDecode(v.Raw))
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0xc0})
+	f.Add([]byte{0xbf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		trx, err := tx.DecodeBytes(data)
+		if err != nil {
+			return
+		}
+		encoded, err := rlp.EncodeToBytes(trx)
+		if err != nil {
+			t.Fatalf("decoded tx fails to encode: %v", err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip mismatch:\n in: %x\nout: %x", data, encoded)
+		}
+		// derived values must not panic either
+		trx.Signer()
+		trx.ID()
+		trx.SigningHashChecked()
+		trx.IntrinsicGas()
+	})
+}
+
+func TestContractAddress(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	code := []byte{0x60, 0x60, 0x60}
+	trx := newTestTx(tx.NewClause(&to).WithData(code), tx.NewClause(nil).WithData(code))
+
+	if _, err := trx.ContractAddress(1); err == nil {
+		t.Fatal("expected error for unsigned tx")
+	}
+
+	signed := signTestTx(t, trx)
+	if _, err := signed.ContractAddress(0); err == nil {
+		t.Fatal("expected error for clause not creating contract")
+	}
+	if _, err := signed.ContractAddress(2); err == nil {
+		t.Fatal("expected error for clause index out of range")
+	}
+
+	got, err := signed.ContractAddress(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pinned values, the address was checked against an independent blake2b-256(id, clauseIndex, 0)[12:];
+	// a receipt of a real deployment is only checked by TestContractAddressOnChain
+	if id, want := signed.ID(), meter.MustParseBytes32("0xef3d659eae837ec72613b11cbc2693d3d5893686e90f36429bbb607e724111c1"); id != want {
+		t.Fatalf("id = %v, want %v", id, want)
+	}
+	if want := meter.MustParseAddress("0x6a5f0dd2ebf1d570246a65b6f3edfce5302a2d69"); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// TestContractAddressOnChain checks ContractAddress against the receipt of a real deployment.
+// It runs with METER_NODE_URL set to a node, and METER_DEPLOY_TX to the id of a tx creating contracts.
+func TestContractAddressOnChain(t *testing.T) {
+	nodeURL, txID := os.Getenv("METER_NODE_URL"), os.Getenv("METER_DEPLOY_TX")
+	if nodeURL == "" || txID == "" {
+		t.Skip("METER_NODE_URL or METER_DEPLOY_TX not set")
+	}
+	ctx := context.Background()
+	c, err := client.NewClient(nodeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := meter.MustParseBytes32(txID)
+	ct, err := c.GetTransaction(ctx, id, client.GetTxOptions{Raw: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct == nil {
+		t.Fatalf("tx %v not found", id)
+	}
+	r, err := c.GetTransactionReceipt(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r == nil || r.Reverted {
+		t.Fatalf("tx %v not mined or reverted", id)
+	}
+
+	var checked int
+	for i, clause := range ct.Transaction.Clauses() {
+		if !clause.IsCreatingContract() {
+			continue
+		}
+		want := r.Outputs[i].ContractAddress
+		if want == nil {
+			t.Fatalf("clause %d: no contract address in receipt", i)
+		}
+		got, err := ct.Transaction.ContractAddress(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != *want {
+			t.Errorf("clause %d: got %v, want %v from receipt", i, got, *want)
+		}
+		checked++
+	}
+	if checked == 0 {
+		t.Fatalf("tx %v creates no contract", id)
+	}
+}
+
+func TestReservedRoundTrip(t *testing.T) {
+	to := meter.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	clauses := []*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}
+
+	encode := func(reserved ...rlp.RawValue) []byte {
+		if reserved == nil {
+			reserved = []rlp.RawValue{}
+		}
+		data, err := rlp.EncodeToBytes([]interface{}{
+			uint8(1),           // chain tag
+			uint64(0xaabbccdd), // block ref
+			uint32(32),         // expiration
+			clauses,
+			uint8(0),      // gas price coef
+			uint64(21000), // gas
+			[]byte{},      // depends on
+			uint64(1),     // nonce
+			reserved,
+			[]byte{}, // signature
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	var (
+		delegated = rlp.RawValue{0x01}
+		noFeature = rlp.RawValue{0x80}
+		unknown   = rlp.RawValue{0x83, 0xab, 0xcd, 0xef}
+		list      = rlp.RawValue{0xc2, 0x01, 0x02}
+		empty     = rlp.RawValue{0x80}
+	)
+	tests := []struct {
+		name      string
+		reserved  []rlp.RawValue
+		hasUnused bool
+		trimmed   bool
+	}{
+		{"none", nil, false, true},
+		{"features", []rlp.RawValue{delegated}, false, true},
+		{"unknown after features", []rlp.RawValue{delegated, unknown}, true, true},
+		{"unknown after empty features", []rlp.RawValue{noFeature, unknown}, true, true},
+		{"unknown list", []rlp.RawValue{delegated, list}, true, true},
+		{"several unknown", []rlp.RawValue{delegated, unknown, list, unknown}, true, true},
+		{"empty inside unknown", []rlp.RawValue{delegated, empty, unknown}, true, true},
+		{"lone empty features", []rlp.RawValue{noFeature}, false, false},
+		{"trailing empty", []rlp.RawValue{delegated, unknown, empty}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := encode(tt.reserved...)
+			trx, err := tx.DecodeBytes(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := rlp.EncodeToBytes(trx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("re-encoded differently:\n got %x\nwant %x", got, data)
+			}
+			if trx.HasReservedFields() != tt.hasUnused {
+				t.Errorf("HasReservedFields: got %v, want %v", trx.HasReservedFields(), tt.hasUnused)
+			}
+			if err := trx.Validate(); (err == nil) != tt.trimmed {
+				t.Errorf("Validate: got %v, trimmed %v", err, tt.trimmed)
+			}
+		})
+	}
+}
+
+// freshTxs returns n decoded copies of a signed tx, with nothing cached.
+func freshTxs(b *testing.B, n int) []*tx.Transaction {
+	raw := hexutil.MustDecode(testvectors.Vectors[0].Raw)
+	txs := make([]*tx.Transaction, n)
+	for i := range txs {
+		trx, err := tx.DecodeBytes(raw)
+		if err != nil {
+			b.Fatal(err)
+		}
+		txs[i] = trx
+	}
+	return txs
+}
+
+func BenchmarkSigner(b *testing.B) {
+	b.Run("cold", func(b *testing.B) {
+		txs := freshTxs(b, b.N)
+		b.ResetTimer()
+		for _, trx := range txs {
+			trx.Signer()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		trx := freshTxs(b, 1)[0]
+		trx.Signer()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			trx.Signer()
+		}
+	})
+}
+
+func BenchmarkID(b *testing.B) {
+	b.Run("cold", func(b *testing.B) {
+		txs := freshTxs(b, b.N)
+		b.ResetTimer()
+		for _, trx := range txs {
+			trx.ID()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		trx := freshTxs(b, 1)[0]
+		trx.ID()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			trx.ID()
+		}
+	})
+}
+
+func BenchmarkSigningHash(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		txs := freshTxs(b, b.N)
+		b.ResetTimer()
+		for _, trx := range txs {
+			trx.SigningHash()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		trx := freshTxs(b, 1)[0]
+		trx.SigningHash()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			trx.SigningHash()
+		}
+	})
+	// verifying a block of signed copies, whose signing hash is carried from the unsigned tx
+	b.Run("signed copies", func(b *testing.B) {
+		unsigned := freshTxs(b, 1)[0].ToBuilder().Build()
+		unsigned.SigningHash()
+		sig := hexutil.MustDecode(testvectors.Vectors[0].Signature)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			unsigned.WithSignature(sig).SigningHash()
+		}
+	})
+}
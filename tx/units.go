@@ -0,0 +1,110 @@
+// Copyright (c) 2020 The Meter developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"meter-go/meter"
+)
+
+// Decimals is the decimals of both MTR and MTRG, i.e. 1 MTR = 1e18 wei.
+const Decimals = 18
+
+var weiPerToken = new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil)
+
+// TransferMTR returns a clause sending amount wei of MTR to the address.
+func TransferMTR(to meter.Address, amount *big.Int) *Clause {
+	return NewClause(&to).WithValue(amount).WithTokenType(MeterToken)
+}
+
+// TransferMTRG returns a clause sending amount wei of MTRG to the address.
+func TransferMTRG(to meter.Address, amount *big.Int) *Clause {
+	return NewClause(&to).WithValue(amount).WithTokenType(MeterGovToken)
+}
+
+// MTR converts an amount of MTR into wei, e.g. MTR(2.5) is 2.5e18.
+// The float is taken by its shortest decimal form, so MTR(0.1) is exactly 1e17,
+// and digits beyond 18 decimals are truncated. Use Tokens for integers, or ParseAmount for exact input.
+// It panics on NaN, infinity or negative amounts.
+func MTR(whole float64) *big.Int {
+	return floatToWei(whole)
+}
+
+// MTRG converts an amount of MTRG into wei, the same way as MTR.
+func MTRG(whole float64) *big.Int {
+	return floatToWei(whole)
+}
+
+// Tokens converts an integer amount of MTR or MTRG into wei exactly.
+func Tokens(whole uint64) *big.Int {
+	wei := new(big.Int).SetUint64(whole)
+	return wei.Mul(wei, weiPerToken)
+}
+
+// ParseAmount parses an amount like "2.5", "2.5 MTR" or "100MTRG" into wei exactly.
+// The token defaults to MTR without suffix, and more than 18 fractional digits are rejected.
+func ParseAmount(s string) (*big.Int, TokenType, error) {
+	num := strings.TrimSpace(s)
+	token := MeterToken
+	upper := strings.ToUpper(num)
+	switch {
+	case strings.HasSuffix(upper, "MTRG"):
+		num, token = num[:len(num)-4], MeterGovToken
+	case strings.HasSuffix(upper, "MTR"):
+		num = num[:len(num)-3]
+	}
+	wei, err := decimalToWei(strings.TrimSpace(num))
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse amount %q: %w", s, err)
+	}
+	return wei, token, nil
+}
+
+func floatToWei(f float64) *big.Int {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+		panic(fmt.Sprintf("tx: invalid amount %v", f))
+	}
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > Decimals {
+		s = s[:i+1+Decimals]
+	}
+	wei, err := decimalToWei(s)
+	if err != nil {
+		panic(err)
+	}
+	return wei
+}
+
+// decimalToWei converts a non-negative decimal string in unit token into wei exactly.
+// More than 18 fractional digits are rejected.
+func decimalToWei(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("empty amount")
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 && len(s)-i-1 > Decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, Decimals)
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && c != '.' {
+			return nil, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(weiPerToken))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}